@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/dennislwm/unified-security-platform/backend/config"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/database"
+)
+
+// 在空的資料庫建立第一位管理員
+//
+// 環境變數：
+//
+//	BOOTSTRAP_ADMIN_USERNAME   使用者名稱（預設 admin）
+//	BOOTSTRAP_ADMIN_EMAIL      Email（必填）
+//	BOOTSTRAP_ADMIN_PASSWORD   密碼（必填，需符合密碼政策）
+//	BOOTSTRAP_ADMIN_FULL_NAME  姓名（選填）
+//
+// 資料庫已有任何使用者時不做任何事並正常結束，可安全地在每次部署時執行。
+func main() {
+	req := &dto.CreateUserRequest{
+		Username: getEnv("BOOTSTRAP_ADMIN_USERNAME", "admin"),
+		Email:    os.Getenv("BOOTSTRAP_ADMIN_EMAIL"),
+		Password: os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"),
+		FullName: os.Getenv("BOOTSTRAP_ADMIN_FULL_NAME"),
+	}
+	if req.Email == "" || req.Password == "" {
+		log.Fatal("❌ 請設定 BOOTSTRAP_ADMIN_EMAIL 與 BOOTSTRAP_ADMIN_PASSWORD")
+	}
+
+	// 載入配置
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ 載入配置失敗: %v", err)
+	}
+
+	// 連接資料庫
+	db, err := database.NewPostgresDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ 資料庫連接失敗: %v", err)
+	}
+	defer database.Close(db)
+
+	userService := service.NewUserService(repository.NewUserRepository(db))
+	admin, err := userService.BootstrapAdmin(req)
+	if errors.Is(err, service.ErrUsersExist) {
+		fmt.Println("ℹ️  資料庫已有使用者，略過建立初始管理員")
+		return
+	}
+	if err != nil {
+		log.Fatalf("❌ 建立初始管理員失敗: %v", err)
+	}
+
+	fmt.Printf("✅ 已建立初始管理員 %s（ID %d）\n", admin.Username, admin.ID)
+}
+
+// getEnv 取得環境變數，未設定時使用預設值
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
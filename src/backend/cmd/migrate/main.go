@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/config"
+	"github.com/dennislwm/unified-security-platform/backend/database/migrations"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/database"
+)
+
+const usage = `用法: migrate <command>
+
+指令:
+  up          套用所有尚未執行的遷移
+  down [N]    回滾最近 N 個遷移（預設 1）
+  status      顯示每個遷移的套用狀態`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	// 載入配置
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ 載入配置失敗: %v", err)
+	}
+
+	// 連接資料庫
+	db, err := database.NewPostgresDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ 資料庫連接失敗: %v", err)
+	}
+	defer database.Close(db)
+
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		log.Fatalf("❌ 載入遷移檔案失敗: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			log.Fatalf("❌ 遷移失敗（已套用 %d 個）: %v", applied, err)
+		}
+		fmt.Printf("✅ 已套用 %d 個遷移\n", applied)
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("❌ 無效的回滾數量: %s", os.Args[2])
+			}
+		}
+		rolledBack, err := migrator.Down(steps)
+		if err != nil {
+			log.Fatalf("❌ 回滾失敗（已回滾 %d 個）: %v", rolledBack, err)
+		}
+		fmt.Printf("✅ 已回滾 %d 個遷移\n", rolledBack)
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("❌ 查詢遷移狀態失敗: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			appliedAt := ""
+			if s.Applied {
+				state = "applied"
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d  %-8s  %-40s  %s\n", s.Version, state, s.Name, appliedAt)
+		}
+
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+}
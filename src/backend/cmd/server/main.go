@@ -11,7 +11,19 @@ import (
 	"time"
 
 	"github.com/dennislwm/unified-security-platform/backend/config"
+	"github.com/dennislwm/unified-security-platform/backend/database/migrations"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/buildinfo"
+	"github.com/dennislwm/unified-security-platform/backend/internal/handler"
+	"github.com/dennislwm/unified-security-platform/backend/internal/metrics"
+	"github.com/dennislwm/unified-security-platform/backend/internal/middleware"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/scanner"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/internal/worker"
 	"github.com/dennislwm/unified-security-platform/backend/pkg/database"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/hexstrike"
 	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
 	"github.com/dennislwm/unified-security-platform/backend/pkg/redis"
 	"github.com/gin-gonic/gin"
@@ -37,6 +49,11 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey ApiKey
+// @in header
+// @name X-API-Key
+// @description API key created via POST /me/api-keys.
+
 func main() {
 	// 載入配置
 	cfg, err := config.Load()
@@ -46,7 +63,7 @@ func main() {
 
 	// 初始化 logger
 	logger := logger.NewLogger(cfg.Server.Mode)
-	logger.Info("🚀 啟動統一安全平台後端服務")
+	logger.Info("🚀 啟動統一安全平台後端服務", "version", buildinfo.Version, "commit", buildinfo.Commit)
 
 	// 連接資料庫
 	db, err := database.NewPostgresDB(&cfg.Database)
@@ -55,6 +72,21 @@ func main() {
 	}
 	logger.Info("✅ PostgreSQL 連接成功")
 
+	// 檢查資料庫結構版本
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		logger.Fatal("❌ 載入遷移檔案失敗", "error", err)
+	}
+	pending, err := migrator.Pending()
+	if err != nil {
+		logger.Fatal("❌ 查詢資料庫結構版本失敗", "error", err)
+	}
+	if len(pending) > 0 {
+		logger.Fatal("❌ 資料庫結構版本落後，請先執行 migrate up",
+			"pending", len(pending), "latest", migrator.LatestVersion())
+	}
+	logger.Info("✅ 資料庫結構為最新版本", "version", migrator.LatestVersion())
+
 	// 連接 Redis
 	redisClient := redis.NewRedisClient(&cfg.Redis)
 	if err := redisClient.Ping(context.Background()); err != nil {
@@ -63,6 +95,70 @@ func main() {
 		logger.Info("✅ Redis 連接成功")
 	}
 
+	// 建立各層依賴（repository → service → handler）
+	scanRepo := repository.NewScanRepository(db)
+	assetRepo := repository.NewAssetRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	eventRepo := repository.NewSecurityEventRepository(db)
+	findingRepo := repository.NewFindingRepository(db)
+	issueRepo := repository.NewIssueRepository(db)
+	tokenManager := auth.NewTokenManager(cfg.JWT.Secret, cfg.JWT.Expiration, cfg.JWT.RefreshExpiration)
+	scanService := service.NewScanService(scanRepo, assetRepo, findingRepo, issueRepo)
+	assetService := service.NewAssetService(assetRepo)
+	authService := service.NewAuthService(userRepo, tokenManager, redisClient)
+	auditService := service.NewAuditService(auditRepo)
+	userService := service.NewUserService(userRepo)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	eventService := service.NewSecurityEventService(eventRepo, userRepo)
+	findingService := service.NewFindingService(findingRepo, userRepo)
+	issueService := service.NewIssueService(issueRepo)
+	metricsService := service.NewMetricsService(scanRepo, issueRepo, eventRepo, redisClient)
+	scanHandler := handler.NewScanHandler(scanService)
+	assetHandler := handler.NewAssetHandler(assetService)
+	authHandler := handler.NewAuthHandler(authService)
+	userHandler := handler.NewUserHandler(userService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	eventHandler := handler.NewSecurityEventHandler(eventService)
+	findingHandler := handler.NewFindingHandler(findingService)
+	issueHandler := handler.NewIssueHandler(issueService)
+	metricsHandler := handler.NewMetricsHandler(metricsService)
+	healthService := service.NewHealthService(db, redisClient, migrator, cfg.Services.HexStrikeURL, cfg.Services.AIQuantumURL, logger)
+	healthHandler := handler.NewHealthHandler(healthService)
+
+	// HexStrike AI：worker 執行 hexstrike 掃描；整合端點等待掃描結束的上限需低於伺服器寫入逾時
+	hexStrikeClient := hexstrike.NewClient(&cfg.Services)
+	hexStrikeService := service.NewHexStrikeService(scanRepo, scanService, hexStrikeClient, cfg.Server.WriteTimeout-2*time.Second)
+	hexStrikeHandler := handler.NewHexStrikeHandler(hexStrikeService)
+
+	// Prometheus 指標；掃描結果寫入時更新指標並使指標摘要快取失效
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Fatal("❌ 取得資料庫連線池失敗", "error", err)
+	}
+	metricsRegistry := metrics.NewRegistry(scanRepo, sqlDB, redisClient)
+	scanService.AddObserver(metricsRegistry)
+	scanService.AddObserver(metricsService)
+
+	// 啟動背景掃描 worker
+	scanRegistry := scanner.NewRegistry(
+		scanner.NewCommandScanner("nuclei", cfg.Scanner.NucleiBin, cfg.Scanner.NucleiArgs, parser.Findings(parser.ParseNuclei)),
+		scanner.NewCommandScanner("nmap", cfg.Scanner.NmapBin, cfg.Scanner.NmapArgs, parser.Findings(parser.ParseNmap)),
+		scanner.NewCommandScanner("amass", cfg.Scanner.AmassBin, cfg.Scanner.AmassArgs, parser.Assets(parser.ParseAmass)),
+		scanner.NewHexStrikeScanner(hexStrikeClient),
+	)
+	scanWorker := worker.NewScanWorker(scanService, scanRegistry, logger,
+		cfg.Scanner.Workers, cfg.Scanner.PollInterval, cfg.Scanner.Timeout, cfg.Scanner.StaleGrace)
+	scanService.SetCanceller(scanWorker)
+
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
+	go func() {
+		scanWorker.Run(workerCtx)
+		close(workerDone)
+	}()
+
 	// 設定 Gin 模式
 	gin.SetMode(cfg.Server.Mode)
 
@@ -73,73 +169,42 @@ func main() {
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(corsMiddleware())
+	router.Use(middleware.Metrics(metricsRegistry))
+	router.Use(middleware.ErrorHandler(logger))
 
 	// 健康檢查端點
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "unified-security-platform-backend",
-			"version": "1.0.0",
-			"time":    time.Now().Format(time.RFC3339),
-		})
-	})
-
-	// API v1 路由組
-	v1 := router.Group("/api/v1")
-	{
-		// 掃描管理
-		scans := v1.Group("/scans")
-		{
-			scans.GET("", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "掃描列表", "data": []string{}})
-			})
-			scans.POST("", func(c *gin.Context) {
-				c.JSON(http.StatusCreated, gin.H{"message": "建立掃描任務"})
-			})
-			scans.GET("/:id", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "掃描詳情", "id": c.Param("id")})
-			})
-		}
-
-		// 安全事件
-		events := v1.Group("/security-events")
-		{
-			events.GET("", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "安全事件列表", "data": []string{}})
-			})
-		}
-
-		// 監控指標
-		metrics := v1.Group("/metrics")
-		{
-			metrics.GET("/summary", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{
-					"scans_total":     0,
-					"events_total":    0,
-					"threats_blocked": 0,
-				})
-			})
-		}
-
-		// 整合端點
-		integration := v1.Group("/integration")
-		{
-			// 呼叫 HexStrike AI
-			integration.POST("/hexstrike/scan", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "HexStrike 掃描已觸發"})
-			})
-
-			// 呼叫 AI/量子服務
-			integration.POST("/ai-quantum/analyze", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "AI 威脅分析已觸發"})
-			})
-		}
-	}
+	// /livez 只確認程序存活；/readyz 檢查各依賴；/health 保留為 /readyz 的別名（Docker HEALTHCHECK 使用）
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+	router.GET("/health", healthHandler.Readyz)
+
+	// API v1 路由組（端點與所需權限見 routes.go）
+	authz := middleware.NewAuthorizer(auditService, logger)
+	registerRoutes(router.Group("/api/v1"), apiEndpoints(
+		&apiHandlers{
+			auth:      authHandler,
+			user:      userHandler,
+			apiKey:    apiKeyHandler,
+			scan:      scanHandler,
+			finding:   findingHandler,
+			issue:     issueHandler,
+			asset:     assetHandler,
+			event:     eventHandler,
+			metrics:   metricsHandler,
+			hexStrike: hexStrikeHandler,
+		},
+		&apiGuards{
+			requireAuth:    middleware.Auth(authService, apiKeyService),
+			requireSession: authz.RequireSession(),
+			canRead:        authz.Require(auth.PermissionRead),
+			canWrite:       authz.Require(auth.PermissionWrite),
+			canTriage:      authz.Require(auth.PermissionTriage),
+			isAdmin:        authz.Require(auth.PermissionAdmin),
+		},
+	))
 
 	// Prometheus 指標端點
-	router.GET("/metrics/prometheus", func(c *gin.Context) {
-		c.String(http.StatusOK, "# Prometheus metrics endpoint\n")
-	})
+	router.GET("/metrics/prometheus", gin.WrapH(metricsRegistry.Handler()))
 
 	// Swagger 文件（開發環境）
 	if cfg.Server.Mode == "debug" {
@@ -160,7 +225,7 @@ func main() {
 	go func() {
 		logger.Info(fmt.Sprintf("🌐 HTTP 服務器啟動於 http://%s:%d", cfg.Server.Host, cfg.Server.Port))
 		logger.Info(fmt.Sprintf("📖 API 文件： http://%s:%d/swagger/index.html", cfg.Server.Host, cfg.Server.Port))
-		logger.Info(fmt.Sprintf("❤️  健康檢查： http://%s:%d/health", cfg.Server.Host, cfg.Server.Port))
+		logger.Info(fmt.Sprintf("❤️  健康檢查： http://%s:%d/readyz", cfg.Server.Host, cfg.Server.Port))
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Fatal("❌ 服務器啟動失敗", "error", err)
 		}
@@ -181,12 +246,17 @@ func main() {
 		logger.Fatal("❌ 服務器強制關閉", "error", err)
 	}
 
-	// 關閉資料庫連接
-	sqlDB, _ := db.DB()
-	if sqlDB != nil {
-		sqlDB.Close()
+	// 停止背景掃描 worker，等待執行中的任務結束
+	stopWorker()
+	select {
+	case <-workerDone:
+	case <-ctx.Done():
+		logger.Warn("⚠️  等待掃描 worker 停止逾時")
 	}
 
+	// 關閉資料庫連接
+	sqlDB.Close()
+
 	// 關閉 Redis 連接
 	redisClient.Close()
 
@@ -198,7 +268,7 @@ func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+middleware.APIKeyHeader+", accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
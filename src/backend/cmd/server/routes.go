@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// apiHandlers API v1 路由使用的處理器
+type apiHandlers struct {
+	auth      *handler.AuthHandler
+	user      *handler.UserHandler
+	apiKey    *handler.APIKeyHandler
+	scan      *handler.ScanHandler
+	finding   *handler.FindingHandler
+	issue     *handler.IssueHandler
+	asset     *handler.AssetHandler
+	event     *handler.SecurityEventHandler
+	metrics   *handler.MetricsHandler
+	hexStrike *handler.HexStrikeHandler
+}
+
+// apiGuards 認證與授權中間件
+type apiGuards struct {
+	requireAuth    gin.HandlerFunc // 驗證 JWT 或 API 金鑰
+	requireSession gin.HandlerFunc // 只接受 JWT
+	canRead        gin.HandlerFunc
+	canWrite       gin.HandlerFunc
+	canTriage      gin.HandlerFunc
+	isAdmin        gin.HandlerFunc
+}
+
+// endpoint 單一 API 端點；path 相對於 /api/v1，guards 依序在 handler 之前執行
+type endpoint struct {
+	method  string
+	path    string
+	guards  []gin.HandlerFunc
+	handler gin.HandlerFunc
+}
+
+// apiEndpoints API v1 的所有端點與所需權限
+func apiEndpoints(h *apiHandlers, g *apiGuards) []endpoint {
+	guards := func(fns ...gin.HandlerFunc) []gin.HandlerFunc { return fns }
+	authed := func(fn gin.HandlerFunc) []gin.HandlerFunc { return guards(g.requireAuth, fn) }
+	session := guards(g.requireAuth, g.requireSession)
+
+	return []endpoint{
+		// 認證
+		{http.MethodPost, "/auth/login", nil, h.auth.Login},
+		{http.MethodPost, "/auth/refresh", nil, h.auth.Refresh},
+		{http.MethodPost, "/auth/logout", session, h.auth.Logout},
+
+		// 目前使用者
+		{http.MethodGet, "/me", guards(g.requireAuth), h.user.GetMe},
+		{http.MethodPatch, "/me", session, h.user.UpdateMe},
+		{http.MethodPut, "/me/password", session, h.user.ChangePassword},
+		{http.MethodGet, "/me/api-keys", session, h.apiKey.GetAPIKeys},
+		{http.MethodPost, "/me/api-keys", session, h.apiKey.CreateAPIKey},
+		{http.MethodDelete, "/me/api-keys/:id", session, h.apiKey.RevokeAPIKey},
+
+		// 使用者管理（僅限管理員）
+		{http.MethodGet, "/users", authed(g.isAdmin), h.user.GetUsers},
+		{http.MethodPost, "/users", authed(g.isAdmin), h.user.CreateUser},
+		{http.MethodGet, "/users/:id", authed(g.isAdmin), h.user.GetUser},
+		{http.MethodPatch, "/users/:id", authed(g.isAdmin), h.user.UpdateUser},
+		{http.MethodDelete, "/users/:id", authed(g.isAdmin), h.user.DeleteUser},
+
+		// 掃描管理
+		{http.MethodGet, "/scans", authed(g.canRead), h.scan.GetScans},
+		{http.MethodPost, "/scans", authed(g.canWrite), h.scan.CreateScan},
+		{http.MethodGet, "/scans/metrics", authed(g.canRead), h.scan.GetMetrics},
+		{http.MethodGet, "/scans/:id", authed(g.canRead), h.scan.GetScan},
+		{http.MethodGet, "/scans/:id/diff", authed(g.canRead), h.scan.DiffScan},
+		{http.MethodPatch, "/scans/:id", authed(g.canWrite), h.scan.UpdateScanStatus},
+		{http.MethodDelete, "/scans/:id", authed(g.isAdmin), h.scan.DeleteScan},
+		{http.MethodPost, "/scans/:id/cancel", authed(g.canWrite), h.scan.CancelScan},
+		{http.MethodPost, "/scans/:id/retry", authed(g.canWrite), h.scan.RetryScan},
+		{http.MethodPost, "/scans/:id/results", authed(g.canWrite), h.scan.UploadResults},
+
+		// 掃描發現
+		{http.MethodGet, "/findings", authed(g.canRead), h.finding.GetFindings},
+		{http.MethodPost, "/findings/triage", authed(g.canTriage), h.finding.BulkTriage},
+		{http.MethodPatch, "/findings/:id", authed(g.canTriage), h.finding.TriageFinding},
+
+		// 跨掃描問題
+		{http.MethodGet, "/issues", authed(g.canRead), h.issue.GetIssues},
+		{http.MethodGet, "/issues/:id", authed(g.canRead), h.issue.GetIssue},
+
+		// 資產清單
+		{http.MethodGet, "/assets", authed(g.canRead), h.asset.GetAssets},
+		{http.MethodGet, "/assets/:id", authed(g.canRead), h.asset.GetAsset},
+
+		// 安全事件
+		{http.MethodGet, "/security-events", authed(g.canRead), h.event.GetEvents},
+		{http.MethodPost, "/security-events", authed(g.canWrite), h.event.CreateEvent},
+		{http.MethodGet, "/security-events/metrics", authed(g.canRead), h.event.GetMetrics},
+		{http.MethodGet, "/security-events/:id", authed(g.canRead), h.event.GetEvent},
+		{http.MethodPut, "/security-events/:id/assignee", authed(g.canTriage), h.event.AssignEvent},
+		{http.MethodPut, "/security-events/:id/status", authed(g.canTriage), h.event.UpdateEventStatus},
+
+		// 監控指標
+		{http.MethodGet, "/metrics/summary", authed(g.canRead), h.metrics.GetSummary},
+		{http.MethodGet, "/metrics/trends", authed(g.canRead), h.metrics.GetTrends},
+
+		// 整合端點：透過 HexStrike AI 執行掃描工具、呼叫 AI/量子服務
+		{http.MethodPost, "/integration/hexstrike/scan", authed(g.canWrite), h.hexStrike.CreateScan},
+		{http.MethodPost, "/integration/ai-quantum/analyze", authed(g.canWrite), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "AI 威脅分析已觸發"})
+		}},
+	}
+}
+
+// registerRoutes 將端點註冊到路由組
+func registerRoutes(group *gin.RouterGroup, endpoints []endpoint) {
+	for _, e := range endpoints {
+		handlers := make([]gin.HandlerFunc, 0, len(e.guards)+1)
+		handlers = append(handlers, e.guards...)
+		handlers = append(handlers, e.handler)
+		group.Handle(e.method, e.path, handlers...)
+	}
+}
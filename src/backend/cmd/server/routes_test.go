@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/middleware"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// access 路由要求的存取層級
+type access string
+
+const (
+	public        access = "public"        // 不需認證
+	authenticated access = "authenticated" // JWT 或 API 金鑰
+	session       access = "session"       // 只接受 JWT
+	read          access = access(auth.PermissionRead)
+	write         access = access(auth.PermissionWrite)
+	triage        access = access(auth.PermissionTriage)
+	admin         access = access(auth.PermissionAdmin)
+)
+
+// routeAccess 每個 API v1 路由預期的存取層級（新增路由時需一併加入）
+var routeAccess = map[string]access{
+	"POST /auth/login":   public,
+	"POST /auth/refresh": public,
+	"POST /auth/logout":  session,
+
+	"GET /me":                 authenticated,
+	"PATCH /me":               session,
+	"PUT /me/password":        session,
+	"GET /me/api-keys":        session,
+	"POST /me/api-keys":       session,
+	"DELETE /me/api-keys/:id": session,
+
+	"GET /users":        admin,
+	"POST /users":       admin,
+	"GET /users/:id":    admin,
+	"PATCH /users/:id":  admin,
+	"DELETE /users/:id": admin,
+
+	"GET /scans":              read,
+	"POST /scans":             write,
+	"GET /scans/metrics":      read,
+	"GET /scans/:id":          read,
+	"GET /scans/:id/diff":     read,
+	"PATCH /scans/:id":        write,
+	"DELETE /scans/:id":       admin,
+	"POST /scans/:id/cancel":  write,
+	"POST /scans/:id/retry":   write,
+	"POST /scans/:id/results": write,
+
+	"GET /findings":         read,
+	"POST /findings/triage": triage,
+	"PATCH /findings/:id":   triage,
+
+	"GET /issues":     read,
+	"GET /issues/:id": read,
+
+	"GET /assets":     read,
+	"GET /assets/:id": read,
+
+	"GET /security-events":              read,
+	"POST /security-events":             write,
+	"GET /security-events/metrics":      read,
+	"GET /security-events/:id":          read,
+	"PUT /security-events/:id/assignee": triage,
+	"PUT /security-events/:id/status":   triage,
+
+	"GET /metrics/summary": read,
+	"GET /metrics/trends":  read,
+
+	"POST /integration/hexstrike/scan":     write,
+	"POST /integration/ai-quantum/analyze": write,
+}
+
+// roleGrants 各角色具備的權限
+var roleGrants = map[string][]access{
+	"readonly": {read},
+	"user":     {read, write},
+	"analyst":  {read, write, triage},
+	"admin":    {read, write, triage, admin},
+}
+
+// principal 發出請求的身分：JWT 使用者、API 金鑰或匿名
+type principal struct {
+	name   string
+	role   string   // 空字串為匿名
+	apiKey bool     // 以 API 金鑰認證
+	scopes []string // API 金鑰的 scopes
+}
+
+var principals = []principal{
+	{name: "anonymous"},
+	{name: "jwt readonly", role: "readonly"},
+	{name: "jwt user", role: "user"},
+	{name: "jwt analyst", role: "analyst"},
+	{name: "jwt admin", role: "admin"},
+	{name: "admin key [read]", role: "admin", apiKey: true, scopes: []string{"read"}},
+	{name: "admin key [write]", role: "admin", apiKey: true, scopes: []string{"write"}},
+	{name: "admin key [triage]", role: "admin", apiKey: true, scopes: []string{"triage"}},
+	{name: "admin key [all]", role: "admin", apiKey: true, scopes: []string{"read", "write", "triage", "admin"}},
+	{name: "user key [all]", role: "user", apiKey: true, scopes: []string{"read", "write", "triage", "admin"}},
+	{name: "readonly key [read,write]", role: "readonly", apiKey: true, scopes: []string{"read", "write"}},
+}
+
+// expectedStatus 身分存取路由時預期的狀態碼（通過授權時 stub handler 回傳 204）
+func (p principal) expectedStatus(required access) int {
+	switch {
+	case required == public:
+		return http.StatusNoContent
+	case p.role == "":
+		return http.StatusUnauthorized
+	case required == authenticated:
+		return http.StatusNoContent
+	case required == session:
+		if p.apiKey {
+			return http.StatusForbidden
+		}
+		return http.StatusNoContent
+	}
+
+	granted := contains(roleGrants[p.role], required)
+	if p.apiKey {
+		granted = granted && contains(p.scopes, string(required))
+	}
+	if granted {
+		return http.StatusNoContent
+	}
+	return http.StatusForbidden
+}
+
+// authorize 設定請求的認證標頭：JWT 以角色作為 token，API 金鑰為「角色:scopes」
+func (p principal) authorize(req *http.Request) {
+	switch {
+	case p.role == "":
+	case p.apiKey:
+		req.Header.Set(middleware.APIKeyHeader, p.role+":"+strings.Join(p.scopes, ","))
+	default:
+		req.Header.Set("Authorization", "Bearer "+p.role)
+	}
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTokens 以角色名稱作為 access token
+type fakeTokens struct{}
+
+func (fakeTokens) Authenticate(ctx context.Context, token string) (*model.User, *auth.Claims, error) {
+	if _, ok := roleGrants[token]; !ok {
+		return nil, nil, service.ErrInvalidToken
+	}
+	return &model.User{ID: 1, Username: token, Role: token, IsActive: true}, &auth.Claims{Role: token}, nil
+}
+
+// fakeAPIKeys 以「角色:scopes」作為 API 金鑰
+type fakeAPIKeys struct{}
+
+func (fakeAPIKeys) Authenticate(key string) (*model.User, *model.APIKey, error) {
+	role, scopes, _ := strings.Cut(key, ":")
+	if _, ok := roleGrants[role]; !ok {
+		return nil, nil, service.ErrInvalidAPIKey
+	}
+	user := &model.User{ID: 2, Username: role, Role: role, IsActive: true}
+	return user, &model.APIKey{ID: 7, UserID: user.ID, Scopes: "{" + scopes + "}"}, nil
+}
+
+// auditEntry 寫入的稽核紀錄
+type auditEntry struct {
+	action string
+	method string
+	path   string
+	userID uint
+}
+
+// fakeAudit 記錄寫入的稽核紀錄
+type fakeAudit struct {
+	mu      sync.Mutex
+	entries []auditEntry
+}
+
+func (f *fakeAudit) Record(user *model.User, action, outcome string, req service.AuditRequest, details map[string]interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry := auditEntry{action: action, method: req.Method, path: req.Path}
+	if user != nil {
+		entry.userID = user.ID
+	}
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+// take 取出並清空已記錄的稽核紀錄
+func (f *fakeAudit) take() []auditEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries := f.entries
+	f.entries = nil
+	return entries
+}
+
+// newTestRouter 以正式的端點表與權限中間件建立路由，handler 換成回傳 204 的 stub
+func newTestRouter(audit *fakeAudit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log := logger.NewLogger("test")
+	authz := middleware.NewAuthorizer(audit, log)
+
+	endpoints := apiEndpoints(&apiHandlers{}, &apiGuards{
+		requireAuth:    middleware.Auth(fakeTokens{}, fakeAPIKeys{}),
+		requireSession: authz.RequireSession(),
+		canRead:        authz.Require(auth.PermissionRead),
+		canWrite:       authz.Require(auth.PermissionWrite),
+		canTriage:      authz.Require(auth.PermissionTriage),
+		isAdmin:        authz.Require(auth.PermissionAdmin),
+	})
+	for i := range endpoints {
+		endpoints[i].handler = func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	}
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(log))
+	registerRoutes(router.Group("/api/v1"), endpoints)
+	return router
+}
+
+func TestRoutesCoverAccessMatrix(t *testing.T) {
+	router := newTestRouter(&fakeAudit{})
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		key := route.Method + " " + strings.TrimPrefix(route.Path, "/api/v1")
+		registered[key] = true
+		if _, ok := routeAccess[key]; !ok {
+			t.Errorf("route %s is missing from routeAccess", key)
+		}
+	}
+	for key := range routeAccess {
+		if !registered[key] {
+			t.Errorf("routeAccess lists %s but it is not registered", key)
+		}
+	}
+}
+
+func TestRoutePermissionMatrix(t *testing.T) {
+	audit := &fakeAudit{}
+	router := newTestRouter(audit)
+
+	for route, required := range routeAccess {
+		method, path, _ := strings.Cut(route, " ")
+		url := "/api/v1" + strings.ReplaceAll(path, ":id", "1")
+
+		for _, p := range principals {
+			req := httptest.NewRequest(method, url, nil)
+			p.authorize(req)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			want := p.expectedStatus(required)
+			if w.Code != want {
+				t.Errorf("%s as %s = %d, want %d (%s)", route, p.name, w.Code, want, strings.TrimSpace(w.Body.String()))
+			}
+
+			// 403 必須寫入一筆稽核紀錄，其他結果不寫入
+			entries := audit.take()
+			if want != http.StatusForbidden {
+				if len(entries) != 0 {
+					t.Errorf("%s as %s wrote audit entries %+v", route, p.name, entries)
+				}
+				continue
+			}
+
+			wantAction := "permission_denied"
+			if required == session {
+				wantAction = "session_required"
+			}
+			if len(entries) != 1 {
+				t.Errorf("%s as %s wrote %d audit entries, want 1", route, p.name, len(entries))
+				continue
+			}
+			if e := entries[0]; e.action != wantAction || e.method != method || e.path != "/api/v1"+path || e.userID == 0 {
+				t.Errorf("%s as %s audit entry = %+v, want %s", route, p.name, e, wantAction)
+			}
+		}
+	}
+}
+
+func TestRoutesRejectInvalidCredentials(t *testing.T) {
+	router := newTestRouter(&fakeAudit{})
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{"unknown token", "Authorization", "Bearer nobody"},
+		{"basic auth", "Authorization", "Basic YWRtaW46YWRtaW4="},
+		{"unknown api key", middleware.APIKeyHeader, "nobody:read"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+		req.Header.Set(tt.header, tt.value)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s = %d, want 401", tt.name, w.Code)
+		}
+	}
+}
@@ -14,6 +14,7 @@ type Config struct {
 	Redis    RedisConfig
 	JWT      JWTConfig
 	Services ServicesConfig
+	Scanner  ScannerConfig
 }
 
 // ServerConfig HTTP 伺服器配置
@@ -48,8 +49,9 @@ type RedisConfig struct {
 
 // JWTConfig JWT 認證配置
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret            string
+	Expiration        time.Duration
+	RefreshExpiration time.Duration
 }
 
 // ServicesConfig 外部服務配置
@@ -59,6 +61,26 @@ type ServicesConfig struct {
 	VaultAddr     string // Vault 地址
 	VaultToken    string // Vault Token
 	PrometheusURL string // Prometheus URL
+
+	// HexStrike AI 客戶端：單次工具執行逾時、失敗重試次數與斷路器（連續失敗次數、開路冷卻時間）
+	HexStrikeTimeout         time.Duration
+	HexStrikeRetries         int
+	HexStrikeBreakerFailures int
+	HexStrikeBreakerCooldown time.Duration
+}
+
+// ScannerConfig 掃描執行引擎配置
+type ScannerConfig struct {
+	Workers      int           // 同時執行的掃描任務數
+	PollInterval time.Duration // 輪詢待執行任務的間隔
+	Timeout      time.Duration // 單一掃描任務的執行上限
+	StaleGrace   time.Duration // running 任務超過 Timeout 加上此時間仍未結束時視為 worker 中斷並標記為失敗
+	NucleiBin    string        // nuclei 執行檔路徑
+	NucleiArgs   string        // nuclei 參數（支援 {target}、{output} 佔位符）
+	NmapBin      string        // nmap 執行檔路徑
+	NmapArgs     string        // nmap 參數
+	AmassBin     string        // amass 執行檔路徑
+	AmassArgs    string        // amass 參數
 }
 
 // Load 從環境變數載入配置
@@ -89,8 +111,9 @@ func Load() (*Config, error) {
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-			Expiration: getEnvAsDuration("JWT_EXPIRATION", 24*time.Hour),
+			Secret:            getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			Expiration:        getEnvAsDuration("JWT_EXPIRATION", 24*time.Hour),
+			RefreshExpiration: getEnvAsDuration("JWT_REFRESH_EXPIRATION", 7*24*time.Hour),
 		},
 		Services: ServicesConfig{
 			HexStrikeURL:  getEnv("HEXSTRIKE_URL", "http://localhost:8888"),
@@ -98,6 +121,23 @@ func Load() (*Config, error) {
 			VaultAddr:     getEnv("VAULT_ADDR", "http://localhost:8200"),
 			VaultToken:    getEnv("VAULT_TOKEN", "root"),
 			PrometheusURL: getEnv("PROMETHEUS_URL", "http://localhost:9090"),
+
+			HexStrikeTimeout:         getEnvAsDuration("HEXSTRIKE_TIMEOUT", 30*time.Minute),
+			HexStrikeRetries:         getEnvAsInt("HEXSTRIKE_RETRIES", 2),
+			HexStrikeBreakerFailures: getEnvAsInt("HEXSTRIKE_BREAKER_FAILURES", 5),
+			HexStrikeBreakerCooldown: getEnvAsDuration("HEXSTRIKE_BREAKER_COOLDOWN", 30*time.Second),
+		},
+		Scanner: ScannerConfig{
+			Workers:      getEnvAsInt("SCANNER_WORKERS", 2),
+			PollInterval: getEnvAsDuration("SCANNER_POLL_INTERVAL", 5*time.Second),
+			Timeout:      getEnvAsDuration("SCANNER_TIMEOUT", time.Hour),
+			StaleGrace:   getEnvAsDuration("SCANNER_STALE_GRACE", 5*time.Minute),
+			NucleiBin:    getEnv("NUCLEI_BIN", "nuclei"),
+			NucleiArgs:   getEnv("NUCLEI_ARGS", "-u {target} -jsonl -silent -o {output}"),
+			NmapBin:      getEnv("NMAP_BIN", "nmap"),
+			NmapArgs:     getEnv("NMAP_ARGS", "-sV --script vulners -oX {output} -- {target}"),
+			AmassBin:     getEnv("AMASS_BIN", "amass"),
+			AmassArgs:    getEnv("AMASS_ARGS", "enum -d {target} -json {output}"),
 		},
 	}
 
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scan_jobs (
+    id            BIGSERIAL PRIMARY KEY,
+    target        VARCHAR(255) NOT NULL,
+    scan_type     VARCHAR(50) NOT NULL,
+    status        VARCHAR(50) DEFAULT 'pending',
+    started_at    TIMESTAMPTZ,
+    completed_at  TIMESTAMPTZ,
+    error_message TEXT,
+    metadata      JSONB DEFAULT '{}',
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    deleted_at    TIMESTAMPTZ,
+    CONSTRAINT chk_scan_jobs_scan_type CHECK (scan_type IN ('nuclei', 'nmap', 'amass', 'custom')),
+    CONSTRAINT chk_scan_jobs_status CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_deleted_at ON scan_jobs (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_status ON scan_jobs (status);
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_created_at ON scan_jobs (created_at DESC);
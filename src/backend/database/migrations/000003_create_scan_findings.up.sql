@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS scan_findings (
+    id            BIGSERIAL PRIMARY KEY,
+    scan_job_id   BIGINT NOT NULL REFERENCES scan_jobs (id) ON DELETE CASCADE,
+    severity      VARCHAR(20) NOT NULL,
+    title         VARCHAR(255) NOT NULL,
+    description   TEXT,
+    host          VARCHAR(255),
+    port          BIGINT,
+    protocol      VARCHAR(20),
+    cvss_score    DECIMAL(3, 1),
+    cve_id        VARCHAR(50),
+    cwe_id        VARCHAR(50),
+    evidence      JSONB DEFAULT '{}',
+    remediation   TEXT,
+    "references"  TEXT[] DEFAULT '{}',
+    discovered_at TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    CONSTRAINT chk_scan_findings_severity CHECK (severity IN ('critical', 'high', 'medium', 'low', 'info'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_scan_findings_scan_job_id ON scan_findings (scan_job_id);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_host ON scan_findings (host);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_discovered_at ON scan_findings (discovered_at);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS security_events (
+    id          BIGSERIAL PRIMARY KEY,
+    event_type  VARCHAR(50) NOT NULL,
+    severity    VARCHAR(20) NOT NULL,
+    source      VARCHAR(255),
+    destination VARCHAR(255),
+    description TEXT NOT NULL,
+    details     JSONB DEFAULT '{}',
+    status      VARCHAR(50) DEFAULT 'open',
+    assigned_to VARCHAR(100),
+    resolved_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    CONSTRAINT chk_security_events_event_type CHECK (event_type IN ('intrusion', 'anomaly', 'threat', 'alert', 'incident')),
+    CONSTRAINT chk_security_events_severity CHECK (severity IN ('critical', 'high', 'medium', 'low', 'info')),
+    CONSTRAINT chk_security_events_status CHECK (status IN ('open', 'investigating', 'resolved', 'false_positive'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_created_at ON security_events (created_at);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS assets (
+    id               BIGSERIAL PRIMARY KEY,
+    hostname         VARCHAR(255) NOT NULL,
+    domain           VARCHAR(255),
+    ip_addresses     TEXT[] DEFAULT '{}',
+    asn              BIGINT,
+    asn_description  VARCHAR(255),
+    source           VARCHAR(50) NOT NULL,
+    sources          TEXT[] DEFAULT '{}',
+    scan_job_id      BIGINT NOT NULL REFERENCES scan_jobs (id) ON DELETE CASCADE,
+    last_scan_job_id BIGINT NOT NULL,
+    first_seen       TIMESTAMPTZ NOT NULL,
+    last_seen        TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assets_hostname ON assets (hostname);
+CREATE INDEX IF NOT EXISTS idx_assets_domain ON assets (domain);
+CREATE INDEX IF NOT EXISTS idx_assets_asn ON assets (asn);
+CREATE INDEX IF NOT EXISTS idx_assets_scan_job_id ON assets (scan_job_id);
+CREATE INDEX IF NOT EXISTS idx_assets_last_scan_job_id ON assets (last_scan_job_id);
+CREATE INDEX IF NOT EXISTS idx_assets_last_seen ON assets (last_seen);
+CREATE INDEX IF NOT EXISTS idx_assets_ip_addresses ON assets USING GIN (ip_addresses);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_scan_jobs_retry_of_id;
+
+ALTER TABLE scan_jobs DROP COLUMN IF EXISTS attempt;
+ALTER TABLE scan_jobs DROP COLUMN IF EXISTS retry_of_id;
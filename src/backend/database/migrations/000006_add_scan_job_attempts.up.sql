@@ -0,0 +1,4 @@
+ALTER TABLE scan_jobs ADD COLUMN IF NOT EXISTS retry_of_id BIGINT REFERENCES scan_jobs (id) ON DELETE SET NULL;
+ALTER TABLE scan_jobs ADD COLUMN IF NOT EXISTS attempt INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_retry_of_id ON scan_jobs (retry_of_id);
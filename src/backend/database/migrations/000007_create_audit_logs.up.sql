@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    username   VARCHAR(100),
+    role       VARCHAR(50),
+    action     VARCHAR(100) NOT NULL,
+    outcome    VARCHAR(20) NOT NULL,
+    method     VARCHAR(10),
+    path       VARCHAR(255),
+    client_ip  VARCHAR(64),
+    details    JSONB DEFAULT '{}',
+    created_at TIMESTAMPTZ,
+    CONSTRAINT chk_audit_logs_outcome CHECK (outcome IN ('allowed', 'denied'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id ON audit_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_security_events_assigned_to;
+DROP INDEX IF EXISTS idx_security_events_severity;
+DROP INDEX IF EXISTS idx_security_events_status;
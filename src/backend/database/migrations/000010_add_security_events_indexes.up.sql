@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_security_events_status ON security_events (status);
+CREATE INDEX IF NOT EXISTS idx_security_events_severity ON security_events (severity);
+CREATE INDEX IF NOT EXISTS idx_security_events_assigned_to ON security_events (assigned_to);
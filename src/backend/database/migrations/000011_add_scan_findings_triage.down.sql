@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_scan_findings_assignee;
+DROP INDEX IF EXISTS idx_scan_findings_triage_status;
+
+ALTER TABLE scan_findings DROP CONSTRAINT IF EXISTS chk_scan_findings_triage_status;
+
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS carried_from_id;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS triaged_at;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS triaged_by;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS risk_accepted_until;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS justification;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS assignee;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS triage_status;
@@ -0,0 +1,13 @@
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS triage_status VARCHAR(20) NOT NULL DEFAULT 'open';
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS assignee VARCHAR(100);
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS justification TEXT;
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS risk_accepted_until TIMESTAMPTZ;
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS triaged_by VARCHAR(100);
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS triaged_at TIMESTAMPTZ;
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS carried_from_id BIGINT REFERENCES scan_findings (id) ON DELETE SET NULL;
+
+ALTER TABLE scan_findings ADD CONSTRAINT chk_scan_findings_triage_status
+    CHECK (triage_status IN ('open', 'confirmed', 'false_positive', 'accepted_risk', 'fixed'));
+
+CREATE INDEX IF NOT EXISTS idx_scan_findings_triage_status ON scan_findings (triage_status);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_assignee ON scan_findings (assignee);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_scan_findings_issue_id;
+DROP INDEX IF EXISTS idx_scan_findings_fingerprint;
+
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS issue_id;
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS fingerprint;
+
+DROP TABLE IF EXISTS issues;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS issues (
+    id                BIGSERIAL PRIMARY KEY,
+    fingerprint       VARCHAR(64) NOT NULL,
+    tool              VARCHAR(50) NOT NULL,
+    target            VARCHAR(255) NOT NULL,
+    severity          VARCHAR(20) NOT NULL,
+    title             VARCHAR(255) NOT NULL,
+    host              VARCHAR(255),
+    port              BIGINT,
+    protocol          VARCHAR(20),
+    cve_id            VARCHAR(50),
+    status            VARCHAR(20) NOT NULL DEFAULT 'open',
+    first_seen        TIMESTAMPTZ,
+    last_seen         TIMESTAMPTZ,
+    occurrence_count  BIGINT NOT NULL DEFAULT 1,
+    first_scan_job_id BIGINT,
+    last_scan_job_id  BIGINT,
+    resolved_at       TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ,
+    CONSTRAINT chk_issues_severity CHECK (severity IN ('critical', 'high', 'medium', 'low', 'info')),
+    CONSTRAINT chk_issues_status CHECK (status IN ('open', 'resolved'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_fingerprint ON issues (fingerprint);
+CREATE INDEX IF NOT EXISTS idx_issues_target ON issues (target);
+CREATE INDEX IF NOT EXISTS idx_issues_status ON issues (status);
+CREATE INDEX IF NOT EXISTS idx_issues_last_seen ON issues (last_seen);
+
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64);
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS issue_id BIGINT REFERENCES issues (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_scan_findings_fingerprint ON scan_findings (fingerprint);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_issue_id ON scan_findings (issue_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_scan_findings_cwe_id;
+DROP INDEX IF EXISTS idx_scan_findings_cve_id;
+DROP INDEX IF EXISTS idx_scan_findings_cvss_sort;
+DROP INDEX IF EXISTS idx_scan_findings_severity_sort;
+
+ALTER TABLE scan_findings DROP COLUMN IF EXISTS severity_score;
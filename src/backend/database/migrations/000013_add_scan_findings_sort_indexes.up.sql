@@ -0,0 +1,16 @@
+-- severity_score 與 model.SeverityScore 相同（critical 5 至 info 1），供排序與 keyset 分頁使用
+ALTER TABLE scan_findings ADD COLUMN IF NOT EXISTS severity_score SMALLINT GENERATED ALWAYS AS (
+    CASE severity
+        WHEN 'critical' THEN 5
+        WHEN 'high' THEN 4
+        WHEN 'medium' THEN 3
+        WHEN 'low' THEN 2
+        WHEN 'info' THEN 1
+        ELSE 0
+    END
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_scan_findings_severity_sort ON scan_findings (severity_score, (COALESCE(cvss_score, -1)), id);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_cvss_sort ON scan_findings ((COALESCE(cvss_score, -1)), severity_score, id);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_cve_id ON scan_findings (cve_id);
+CREATE INDEX IF NOT EXISTS idx_scan_findings_cwe_id ON scan_findings (cwe_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_scan_jobs_target_trgm;
+DROP INDEX IF EXISTS idx_scan_jobs_target_pattern;
+DROP INDEX IF EXISTS idx_scan_jobs_created_at_id;
+
+-- pg_trgm 可能被其他物件使用，不在此移除
@@ -0,0 +1,8 @@
+-- pg_trgm 提供 target 部分比對（LIKE '%x%'）可用的 trigram 索引
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 掃描任務列表的 keyset 分頁鍵（created_at DESC, id DESC）
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_created_at_id ON scan_jobs (created_at DESC, id DESC) WHERE deleted_at IS NULL;
+-- target 前綴比對（LIKE 'x%'），不受資料庫 collation 影響
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_target_pattern ON scan_jobs (target varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_target_trgm ON scan_jobs USING gin (target gin_trgm_ops);
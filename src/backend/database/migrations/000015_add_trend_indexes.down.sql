@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_security_events_resolved_at;
+DROP INDEX IF EXISTS idx_issues_resolved_at;
+DROP INDEX IF EXISTS idx_scan_jobs_completed_at;
+DROP INDEX IF EXISTS idx_scan_jobs_started_at;
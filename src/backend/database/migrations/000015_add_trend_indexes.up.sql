@@ -0,0 +1,5 @@
+-- 趨勢 API 依各時間欄位切分區間（date_trunc）前先以範圍過濾
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_started_at ON scan_jobs (started_at);
+CREATE INDEX IF NOT EXISTS idx_scan_jobs_completed_at ON scan_jobs (completed_at);
+CREATE INDEX IF NOT EXISTS idx_issues_resolved_at ON issues (resolved_at);
+CREATE INDEX IF NOT EXISTS idx_security_events_resolved_at ON security_events (resolved_at);
@@ -0,0 +1,6 @@
+-- 既有的 hexstrike 任務改為 custom 以符合舊的限制
+UPDATE scan_jobs SET scan_type = 'custom' WHERE scan_type = 'hexstrike';
+
+ALTER TABLE scan_jobs DROP CONSTRAINT IF EXISTS chk_scan_jobs_scan_type;
+ALTER TABLE scan_jobs ADD CONSTRAINT chk_scan_jobs_scan_type
+    CHECK (scan_type IN ('nuclei', 'nmap', 'amass', 'custom'));
@@ -0,0 +1,4 @@
+-- hexstrike 掃描由 HexStrike AI 執行工具，工具與參數記錄於 metadata
+ALTER TABLE scan_jobs DROP CONSTRAINT IF EXISTS chk_scan_jobs_scan_type;
+ALTER TABLE scan_jobs ADD CONSTRAINT chk_scan_jobs_scan_type
+    CHECK (scan_type IN ('nuclei', 'nmap', 'amass', 'custom', 'hexstrike'));
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_issues_target_fingerprint;
+
+-- 相同指紋只保留最新的一筆問題（scan_findings.issue_id 依 ON DELETE SET NULL 清除）
+DELETE FROM issues older USING issues newer
+WHERE older.fingerprint = newer.fingerprint AND older.id < newer.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_fingerprint ON issues (fingerprint);
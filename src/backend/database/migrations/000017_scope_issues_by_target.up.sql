@@ -0,0 +1,4 @@
+-- 問題依目標區分：相同指紋出現在不同目標時各自為一筆問題
+-- （升級前已合併到同一筆的問題保留最後寫入的 target，之後的掃描會建立各目標的問題）
+DROP INDEX IF EXISTS idx_issues_fingerprint;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_target_fingerprint ON issues (target, fingerprint);
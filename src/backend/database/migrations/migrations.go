@@ -0,0 +1,12 @@
+// Package migrations 內嵌版本化的 SQL 遷移檔案
+//
+// 檔名格式為 {version}_{name}.up.sql / {version}_{name}.down.sql，
+// 與 golang-migrate 的 -seq 格式相容，可透過 make migrate-create 建立。
+package migrations
+
+import "embed"
+
+// FS 所有 SQL 遷移檔案（編譯時內嵌至二進位檔）
+//
+//go:embed *.sql
+var FS embed.FS
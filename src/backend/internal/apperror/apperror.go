@@ -0,0 +1,120 @@
+package apperror
+
+import (
+	"errors"
+)
+
+// 錯誤類別（以 errors.Is 判斷）
+var (
+	ErrNotFound     = errors.New("not_found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation_failed")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnavailable  = errors.New("service_unavailable")
+)
+
+// Error 領域錯誤：Kind 決定錯誤類別，Code 為穩定的機器可讀代碼，Message 為顯示給使用者的訊息
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Details map[string]interface{}
+	Err     error
+
+	base *Error
+}
+
+// Error 實作 error 介面
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	default:
+		return e.Message + ": " + e.Err.Error()
+	}
+}
+
+// Unwrap 取得底層錯誤
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is 讓 errors.Is(err, ErrNotFound) 等類別判斷成立，經 Wrap 的錯誤也能比對原本的錯誤值
+func (e *Error) Is(target error) bool {
+	if e.base != nil && target == e.base {
+		return true
+	}
+	return e.Kind != nil && target == e.Kind
+}
+
+// WithDetails 回傳附帶額外資訊的副本（errors.Is 仍可比對原本的錯誤值）
+func (e *Error) WithDetails(details map[string]interface{}) *Error {
+	clone := *e
+	clone.Details = details
+	if clone.base == nil {
+		clone.base = e
+	}
+	return &clone
+}
+
+// Wrap 回傳以 err 為底層錯誤的副本（errors.Is 仍可比對原本的錯誤值）
+func (e *Error) Wrap(err error) *Error {
+	clone := *e
+	clone.Err = err
+	if clone.base == nil {
+		clone.base = e
+	}
+	return &clone
+}
+
+// NotFound 建立資源不存在錯誤
+func NotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+// Conflict 建立狀態衝突錯誤
+func Conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+// Validation 建立輸入驗證錯誤
+func Validation(code, message string) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message}
+}
+
+// Forbidden 建立權限不足錯誤
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+// Unauthorized 建立未認證錯誤
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+// Unavailable 建立依賴服務暫時無法使用錯誤
+func Unavailable(code, message string) *Error {
+	return &Error{Kind: ErrUnavailable, Code: code, Message: message}
+}
+
+// Internal 將非預期錯誤包裝為內部錯誤；err 已是領域錯誤時原樣回傳
+func Internal(code string, err error) error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// KindOf 取得錯誤所屬的類別；非領域錯誤回傳 nil
+func KindOf(err error) error {
+	for _, kind := range []error{ErrNotFound, ErrConflict, ErrValidation, ErrForbidden, ErrUnauthorized, ErrUnavailable} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
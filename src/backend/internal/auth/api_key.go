@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// APIKeyPrefix API 金鑰的固定前綴，方便在設定檔與日誌中辨識
+	APIKeyPrefix = "usp_"
+	// apiKeyDisplayLength 列表中顯示的金鑰開頭長度（含前綴）
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+)
+
+// GenerateAPIKey 產生新的 API 金鑰，回傳完整金鑰、顯示用前綴與雜湊值
+// 完整金鑰只在建立時回傳一次，資料庫僅保存雜湊值
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+
+	key = APIKeyPrefix + hex.EncodeToString(b)
+	return key, key[:apiKeyDisplayLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey 計算 API 金鑰的 SHA-256 雜湊值（金鑰為高熵亂數，不需 bcrypt）
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
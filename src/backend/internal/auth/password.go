@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// MinPasswordLength 密碼最短長度
+	MinPasswordLength = 12
+	// MaxPasswordLength 密碼最長長度（bcrypt 只使用前 72 個位元組）
+	MaxPasswordLength = 72
+	// minPasswordClasses 密碼至少需包含的字元類別數（大寫、小寫、數字、符號）
+	minPasswordClasses = 3
+)
+
+// ValidatePassword 檢查密碼是否符合密碼政策
+//
+// 長度 12–72 個位元組、至少包含大寫、小寫、數字、符號其中三類，且不可包含使用者名稱。
+func ValidatePassword(password, username string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("密碼長度至少需 %d 個字元", MinPasswordLength)
+	}
+	if len(password) > MaxPasswordLength {
+		return fmt.Errorf("密碼長度不可超過 %d 個位元組", MaxPasswordLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, ok := range []bool{upper, lower, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < minPasswordClasses {
+		return fmt.Errorf("密碼需包含大寫字母、小寫字母、數字、符號其中至少 %d 類", minPasswordClasses)
+	}
+
+	if username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return errors.New("密碼不可包含使用者名稱")
+	}
+	return nil
+}
+
+// HashPassword 以 bcrypt 雜湊密碼
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword 比對密碼與 bcrypt 雜湊
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// Permission 路由所需的權限
+type Permission string
+
+// 權限等級
+//
+//	read   所有啟用的使用者（readonly 以上）：列表與查詢
+//	write  user / analyst / admin：建立與操作掃描
+//	triage analyst / admin：分流發現與安全事件
+//	admin  admin：刪除掃描、管理使用者
+const (
+	PermissionRead   Permission = "read"
+	PermissionWrite  Permission = "write"
+	PermissionTriage Permission = "triage"
+	PermissionAdmin  Permission = "admin"
+)
+
+// Allowed 判斷使用者是否具備指定權限
+func Allowed(user *model.User, permission Permission) bool {
+	if user == nil || !user.CanRead() {
+		return false
+	}
+
+	switch permission {
+	case PermissionRead:
+		return true
+	case PermissionWrite:
+		return user.CanWrite()
+	case PermissionTriage:
+		return user.IsAnalyst() || user.IsAdmin()
+	case PermissionAdmin:
+		return user.IsAdmin()
+	default:
+		return false
+	}
+}
+
+// ValidPermission 檢查是否為已定義的權限（用於驗證 API 金鑰的 scopes）
+func ValidPermission(permission string) bool {
+	switch Permission(permission) {
+	case PermissionRead, PermissionWrite, PermissionTriage, PermissionAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScopeAllows 檢查 API 金鑰的 scopes 是否包含指定權限（scopes 需逐一明確授予，不會互相涵蓋）
+func ScopeAllows(scopes []string, permission Permission) bool {
+	for _, scope := range scopes {
+		if Permission(scope) == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+)
+
+// 建置時以 -ldflags 注入，例如：
+//
+//	go build -ldflags "-X github.com/dennislwm/unified-security-platform/backend/internal/buildinfo.Version=v1.2.0 \
+//	  -X github.com/dennislwm/unified-security-platform/backend/internal/buildinfo.Commit=abc1234"
+var (
+	// Version 版本號（未注入時為 dev）
+	Version = "dev"
+	// Commit 建置來源的 git commit（未注入時使用 Go 工具鏈記錄的 vcs.revision）
+	Commit = ""
+	// BuildTime 建置時間（RFC3339，未注入時為空）
+	BuildTime = ""
+)
+
+func init() {
+	if Commit != "" {
+		return
+	}
+	Commit = "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				Commit = setting.Value
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest 建立 API 金鑰請求 DTO（scopes 未指定時為 read）
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes,omitempty" binding:"omitempty,dive,oneof=read write triage admin"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// AssetQueryParams 資產查詢參數
+type AssetQueryParams struct {
+	Page      int        `form:"page" binding:"omitempty,min=1"`
+	PageSize  int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Q         string     `form:"q"`
+	Domain    string     `form:"domain"`
+	IP        string     `form:"ip" binding:"omitempty,ip"`
+	ASN       int        `form:"asn" binding:"omitempty,min=1"`
+	Source    string     `form:"source"`
+	ScanJobID uint       `form:"scan_job_id" binding:"omitempty,min=1"`
+	SeenSince *time.Time `form:"seen_since" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// LoginRequest 登入請求 DTO（username 可為使用者名稱或 Email）
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshTokenRequest 刷新 token 請求 DTO
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest 登出請求 DTO（提供 refresh_token 時一併撤銷）
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// TriageFindingRequest 分流掃描發現請求 DTO；未提供的欄位維持不變
+// assignee 為空字串時取消指派；false_positive 與 accepted_risk 需附理由，accepted_risk 需有到期時間
+type TriageFindingRequest struct {
+	TriageStatus      *string    `json:"triage_status,omitempty" binding:"omitempty,oneof=open confirmed false_positive accepted_risk fixed"`
+	Assignee          *string    `json:"assignee,omitempty" binding:"omitempty,max=100"`
+	Justification     *string    `json:"justification,omitempty"`
+	RiskAcceptedUntil *time.Time `json:"risk_accepted_until,omitempty"`
+}
+
+// BulkTriageFindingsRequest 批次分流掃描發現請求 DTO
+type BulkTriageFindingsRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=500,dive,min=1"`
+	TriageFindingRequest
+}
+
+// FindingQueryParams 跨掃描發現查詢參數（keyset 分頁）
+// severity 可用逗號分隔或重複指定多個值；cursor 為上一頁回應的 next_cursor
+type FindingQueryParams struct {
+	Limit          int        `form:"limit" binding:"omitempty,min=1,max=500"`
+	Cursor         string     `form:"cursor"`
+	Sort           string     `form:"sort" binding:"omitempty,oneof=severity cvss"`
+	Order          string     `form:"order" binding:"omitempty,oneof=asc desc"`
+	Severity       []string   `form:"severity"`
+	CVE            string     `form:"cve"`
+	CWE            string     `form:"cwe"`
+	Host           string     `form:"host"`
+	Port           int        `form:"port" binding:"omitempty,min=1,max=65535"`
+	CVSSMin        *float64   `form:"cvss_min" binding:"omitempty,min=0,max=10"`
+	CVSSMax        *float64   `form:"cvss_max" binding:"omitempty,min=0,max=10"`
+	ScanType       string     `form:"scan_type" binding:"omitempty,oneof=nuclei nmap amass custom hexstrike"`
+	Target         string     `form:"target"`
+	TriageStatus   string     `form:"triage_status" binding:"omitempty,oneof=open confirmed false_positive accepted_risk fixed"`
+	DiscoveredFrom *time.Time `form:"discovered_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	DiscoveredTo   *time.Time `form:"discovered_to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
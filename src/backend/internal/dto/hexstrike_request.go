@@ -0,0 +1,14 @@
+package dto
+
+// HexStrikeScanRequest 透過 HexStrike AI 執行工具的掃描請求 DTO
+// Params 只接受各工具允許的參數（見 scanner.ValidateHexStrikeParams），Target 以工具的目標參數送出
+type HexStrikeScanRequest struct {
+	Tool   string                 `json:"tool" binding:"required,max=50"`
+	Target string                 `json:"target" binding:"required"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// HexStrikeScanParams HexStrike 掃描查詢參數；wait 為等待掃描結束的秒數（0 表示不等待）
+type HexStrikeScanParams struct {
+	Wait int `form:"wait" binding:"omitempty,min=0,max=300"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// IssueQueryParams 問題查詢參數
+type IssueQueryParams struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Target   string `form:"target"`
+	Tool     string `form:"tool" binding:"omitempty,max=50"`
+	Status   string `form:"status" binding:"omitempty,oneof=open resolved"`
+	Severity string `form:"severity" binding:"omitempty,oneof=critical high medium low info"`
+	Host     string `form:"host"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// MetricsSummaryParams 平台指標摘要查詢參數
+type MetricsSummaryParams struct {
+	From        *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To          *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	ByTarget    bool       `form:"by_target"`
+	TargetLimit int        `form:"target_limit" binding:"omitempty,min=1,max=100"`
+}
+
+// TrendQueryParams 趨勢查詢參數
+type TrendQueryParams struct {
+	Interval string     `form:"interval" binding:"omitempty,oneof=day week month"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Target   string     `form:"target"`
+	ScanType string     `form:"scan_type" binding:"omitempty,oneof=nuclei nmap amass custom hexstrike"`
+}
@@ -18,8 +18,32 @@ type ScanQueryParams struct {
 	Page     int    `form:"page" binding:"omitempty,min=1"`
 	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
 	Status   string `form:"status" binding:"omitempty,oneof=pending running completed failed cancelled"`
-	ScanType string `form:"scan_type" binding:"omitempty,oneof=nuclei nmap amass custom"`
+	ScanType string `form:"scan_type" binding:"omitempty,oneof=nuclei nmap amass custom hexstrike"`
 	Target   string `form:"target"`
+
+	// keyset 分頁：pagination=cursor 或帶 cursor 時改依 (created_at, id) 分頁，每頁 limit 筆
+	Pagination string `form:"pagination" binding:"omitempty,oneof=page cursor"`
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// TargetMatch 目標比對方式（預設 contains 部分比對，prefix 為前綴比對）
+	// Estimate 以查詢計畫的估計筆數取代 COUNT(*)
+	TargetMatch string `form:"target_match" binding:"omitempty,oneof=contains prefix"`
+	Estimate    bool   `form:"estimate"`
+}
+
+// ScanDiffParams 掃描差異查詢參數
+type ScanDiffParams struct {
+	Against uint   `form:"against" binding:"omitempty,min=1"`
+	Format  string `form:"format" binding:"omitempty,oneof=json markdown"`
+}
+
+// ScanDetailParams 掃描詳情查詢參數；summary 為 true 時只回傳各嚴重程度的統計
+type ScanDetailParams struct {
+	Page     int      `form:"page" binding:"omitempty,min=1"`
+	PageSize int      `form:"page_size" binding:"omitempty,min=1,max=500"`
+	Severity []string `form:"severity"`
+	Summary  bool     `form:"summary"`
 }
 
 
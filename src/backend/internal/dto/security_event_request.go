@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// CreateSecurityEventRequest 建立安全事件請求 DTO
+type CreateSecurityEventRequest struct {
+	EventType   string                 `json:"event_type" binding:"required,oneof=intrusion anomaly threat alert incident"`
+	Severity    string                 `json:"severity" binding:"required,oneof=critical high medium low info"`
+	Source      string                 `json:"source,omitempty" binding:"omitempty,max=255"`
+	Destination string                 `json:"destination,omitempty" binding:"omitempty,max=255"`
+	Description string                 `json:"description" binding:"required"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+}
+
+// AssignSecurityEventRequest 指派安全事件請求 DTO；assigned_to 為空字串時取消指派
+type AssignSecurityEventRequest struct {
+	AssignedTo string `json:"assigned_to" binding:"omitempty,max=100"`
+}
+
+// UpdateSecurityEventStatusRequest 變更安全事件狀態請求 DTO
+type UpdateSecurityEventStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=open investigating resolved false_positive"`
+}
+
+// SecurityEventQueryParams 安全事件查詢參數
+type SecurityEventQueryParams struct {
+	Page       int        `form:"page" binding:"omitempty,min=1"`
+	PageSize   int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+	EventType  string     `form:"event_type" binding:"omitempty,oneof=intrusion anomaly threat alert incident"`
+	Severity   string     `form:"severity" binding:"omitempty,oneof=critical high medium low info"`
+	Status     string     `form:"status" binding:"omitempty,oneof=open investigating resolved false_positive"`
+	AssignedTo string     `form:"assigned_to"`
+	Unassigned bool       `form:"unassigned"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// SecurityEventMetricsParams 安全事件統計查詢參數
+type SecurityEventMetricsParams struct {
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
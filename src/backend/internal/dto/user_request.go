@@ -0,0 +1,40 @@
+package dto
+
+// CreateUserRequest 建立使用者請求 DTO（管理員）
+type CreateUserRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=100"`
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required"`
+	FullName string `json:"full_name,omitempty" binding:"omitempty,max=255"`
+	Role     string `json:"role,omitempty" binding:"omitempty,oneof=admin analyst user readonly"`
+}
+
+// UpdateUserRequest 更新使用者請求 DTO（管理員）；提供 password 時重設密碼
+type UpdateUserRequest struct {
+	Email    *string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	FullName *string `json:"full_name,omitempty" binding:"omitempty,max=255"`
+	Role     *string `json:"role,omitempty" binding:"omitempty,oneof=admin analyst user readonly"`
+	IsActive *bool   `json:"is_active,omitempty"`
+	Password *string `json:"password,omitempty"`
+}
+
+// UpdateProfileRequest 更新個人資料請求 DTO
+type UpdateProfileRequest struct {
+	Email    *string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	FullName *string `json:"full_name,omitempty" binding:"omitempty,max=255"`
+}
+
+// ChangePasswordRequest 變更密碼請求 DTO
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// UserQueryParams 使用者查詢參數
+type UserQueryParams struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Role     string `form:"role" binding:"omitempty,oneof=admin analyst user readonly"`
+	IsActive *bool  `form:"is_active"`
+	Q        string `form:"q"`
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/middleware"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler API 金鑰處理器
+type APIKeyHandler struct {
+	service *service.APIKeyService
+}
+
+// NewAPIKeyHandler 建立新的 APIKeyHandler
+func NewAPIKeyHandler(service *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// GetAPIKeys 取得 API 金鑰列表
+// @Summary 取得 API 金鑰列表
+// @Description 取得目前使用者的 API 金鑰（不含金鑰本身）
+// @Tags me
+// @Produce json
+// @Success 200 {array} vo.APIKeyResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Router /me/api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.service.GetAPIKeys(middleware.CurrentUser(c))
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey 建立 API 金鑰
+// @Summary 建立 API 金鑰
+// @Description 建立供 CI 或自動化代理使用的 API 金鑰；完整金鑰只在此回應出現一次，之後以 X-API-Key 標頭使用
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param key body dto.CreateAPIKeyRequest true "金鑰名稱、權限範圍與過期時間"
+// @Success 201 {object} vo.CreatedAPIKeyResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Router /me/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	key, err := h.service.CreateAPIKey(middleware.CurrentUser(c), &req)
+	if err != nil {
+		c.Error(apperror.Internal("create_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// RevokeAPIKey 撤銷 API 金鑰
+// @Summary 撤銷 API 金鑰
+// @Description 撤銷目前使用者的 API 金鑰，撤銷後立即失效
+// @Tags me
+// @Produce json
+// @Param id path int true "API 金鑰 ID"
+// @Success 200 {object} vo.SuccessResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Router /me/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的 API 金鑰 ID"))
+		return
+	}
+
+	// 呼叫 service
+	if err := h.service.RevokeAPIKey(middleware.CurrentUser(c), uint(id)); err != nil {
+		c.Error(apperror.Internal("revoke_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.SuccessResponse{
+		Success: true,
+		Message: "API 金鑰已撤銷",
+	})
+}
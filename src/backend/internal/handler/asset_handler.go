@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AssetHandler 資產處理器
+type AssetHandler struct {
+	service *service.AssetService
+}
+
+// NewAssetHandler 建立新的 AssetHandler
+func NewAssetHandler(service *service.AssetService) *AssetHandler {
+	return &AssetHandler{service: service}
+}
+
+// GetAssets 取得資產列表
+// @Summary 取得資產列表
+// @Description 取得偵察發現的資產（支援分頁、主機名稱搜尋與過濾）
+// @Tags assets
+// @Produce json
+// @Param page query int false "頁碼" default(1)
+// @Param page_size query int false "每頁數量" default(20)
+// @Param q query string false "主機名稱搜尋"
+// @Param domain query string false "根網域過濾"
+// @Param ip query string false "IP 過濾"
+// @Param asn query int false "ASN 過濾"
+// @Param source query string false "來源工具過濾"
+// @Param scan_job_id query int false "掃描任務過濾"
+// @Param seen_since query string false "最後發現時間下限（RFC3339）"
+// @Success 200 {object} vo.PaginatedResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /assets [get]
+func (h *AssetHandler) GetAssets(c *gin.Context) {
+	var params dto.AssetQueryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	assets, err := h.service.GetAssets(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, assets)
+}
+
+// GetAsset 取得資產詳情
+// @Summary 取得資產詳情
+// @Description 根據 ID 取得資產
+// @Tags assets
+// @Produce json
+// @Param id path int true "資產 ID"
+// @Success 200 {object} vo.AssetResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /assets/{id} [get]
+func (h *AssetHandler) GetAsset(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的資產 ID"))
+		return
+	}
+
+	// 呼叫 service
+	asset, err := h.service.GetAssetByID(uint(id))
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, asset)
+}
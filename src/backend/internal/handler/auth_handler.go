@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/middleware"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler 認證處理器
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+// NewAuthHandler 建立新的 AuthHandler
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Login 登入
+// @Summary 登入
+// @Description 以使用者名稱（或 Email）與密碼登入，取得 access token 與 refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "登入資訊"
+// @Success 200 {object} vo.TokenResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req dto.LoginRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	tokens, err := h.service.Login(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(apperror.Internal("login_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh 刷新 token
+// @Summary 刷新 token
+// @Description 以 refresh token 換發新的 access token 與 refresh token（舊的 refresh token 隨即失效）
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body dto.RefreshTokenRequest true "refresh token"
+// @Success 200 {object} vo.TokenResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	tokens, err := h.service.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		c.Error(apperror.Internal("refresh_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout 登出
+// @Summary 登出
+// @Description 撤銷目前的 access token；body 帶 refresh_token 時一併撤銷
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param logout body dto.LogoutRequest false "refresh token"
+// @Success 200 {object} vo.SuccessResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+
+	// body 可省略
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	if err := h.service.Logout(c.Request.Context(), middleware.CurrentClaims(c), req.RefreshToken); err != nil {
+		c.Error(apperror.Internal("logout_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.SuccessResponse{
+		Success: true,
+		Message: "已登出",
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/middleware"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// FindingHandler 掃描發現處理器
+type FindingHandler struct {
+	service *service.FindingService
+}
+
+// NewFindingHandler 建立新的 FindingHandler
+func NewFindingHandler(service *service.FindingService) *FindingHandler {
+	return &FindingHandler{service: service}
+}
+
+// GetFindings 查詢掃描發現
+// @Summary 查詢掃描發現
+// @Description 跨掃描查詢發現，依嚴重程度或 CVSS 排序並以 cursor 分頁（next_cursor 帶入下一次請求的 cursor）
+// @Tags findings
+// @Produce json
+// @Param limit query int false "每頁數量（最多 500）" default(50)
+// @Param cursor query string false "上一頁回應的 next_cursor"
+// @Param sort query string false "排序（severity、cvss）" default(severity)
+// @Param order query string false "排序方向（asc、desc）" default(desc)
+// @Param severity query []string false "嚴重程度（可逗號分隔或重複指定）" collectionFormat(multi)
+// @Param cve query string false "CVE 過濾"
+// @Param cwe query string false "CWE 過濾"
+// @Param host query string false "主機過濾"
+// @Param port query int false "埠號過濾"
+// @Param cvss_min query number false "CVSS 下限"
+// @Param cvss_max query number false "CVSS 上限"
+// @Param scan_type query string false "掃描類型過濾"
+// @Param target query string false "掃描目標過濾"
+// @Param triage_status query string false "分流狀態過濾"
+// @Param discovered_from query string false "發現時間下限（RFC3339，含）"
+// @Param discovered_to query string false "發現時間上限（RFC3339，不含）"
+// @Success 200 {object} vo.CursorResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /findings [get]
+func (h *FindingHandler) GetFindings(c *gin.Context) {
+	var params dto.FindingQueryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	findings, err := h.service.GetFindings(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, findings)
+}
+
+// TriageFinding 分流掃描發現
+// @Summary 分流掃描發現
+// @Description 更新掃描發現的分流狀態、指派對象、理由與風險接受到期時間；誤報與未到期的風險接受會自動沿用到同一目標之後的掃描
+// @Tags findings
+// @Accept json
+// @Produce json
+// @Param id path int true "掃描發現 ID"
+// @Param triage body dto.TriageFindingRequest true "分流內容"
+// @Success 200 {object} vo.ScanFindingResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /findings/{id} [patch]
+func (h *FindingHandler) TriageFinding(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的掃描發現 ID"))
+		return
+	}
+
+	var req dto.TriageFindingRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	finding, err := h.service.TriageFinding(middleware.CurrentUser(c), uint(id), &req)
+	if err != nil {
+		c.Error(apperror.Internal("triage_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, finding)
+}
+
+// BulkTriage 批次分流掃描發現
+// @Summary 批次分流掃描發現
+// @Description 以相同的分流內容更新多筆掃描發現（最多 500 筆）；任何一筆不存在或不合法時全部不更新
+// @Tags findings
+// @Accept json
+// @Produce json
+// @Param triage body dto.BulkTriageFindingsRequest true "發現 ID 與分流內容"
+// @Success 200 {object} vo.BulkTriageResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /findings/triage [post]
+func (h *FindingHandler) BulkTriage(c *gin.Context) {
+	var req dto.BulkTriageFindingsRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	result, err := h.service.BulkTriage(middleware.CurrentUser(c), &req)
+	if err != nil {
+		c.Error(apperror.Internal("triage_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
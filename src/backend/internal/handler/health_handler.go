@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler 存活與就緒檢查處理器
+type HealthHandler struct {
+	service *service.HealthService
+}
+
+// NewHealthHandler 建立新的 HealthHandler
+func NewHealthHandler(service *service.HealthService) *HealthHandler {
+	return &HealthHandler{service: service}
+}
+
+// Livez 存活檢查
+// @Summary 存活檢查
+// @Description 只確認程序可回應，不檢查依賴；回傳建置時注入的版本與 commit。路徑不在 /api/v1 之下
+// @Tags health
+// @Produce json
+// @Success 200 {object} vo.HealthResponse
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Liveness())
+}
+
+// Readyz 就緒檢查
+// @Summary 就緒檢查
+// @Description 檢查資料庫（含耗時）、資料庫結構版本、Redis 與 HexStrike AI、AI/量子服務的連線狀態。必要依賴失敗時 status 為 unavailable 並回傳 503；僅選用依賴失敗時為 degraded 並回傳 200。回應只包含各依賴的狀態與耗時，失敗原因記錄於伺服器日誌。路徑不在 /api/v1 之下
+// @Tags health
+// @Produce json
+// @Success 200 {object} vo.HealthResponse
+// @Failure 503 {object} vo.HealthResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	response, ready := h.service.Readiness(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// HexStrikeHandler HexStrike AI 整合處理器
+type HexStrikeHandler struct {
+	service *service.HexStrikeService
+}
+
+// NewHexStrikeHandler 建立新的 HexStrikeHandler
+func NewHexStrikeHandler(service *service.HexStrikeService) *HexStrikeHandler {
+	return &HexStrikeHandler{service: service}
+}
+
+// CreateScan 透過 HexStrike AI 執行工具
+// @Summary 透過 HexStrike AI 執行掃描工具
+// @Description 建立 scan_type 為 hexstrike 的掃描任務，由背景 worker 呼叫 HexStrike AI 的 /api/tools/{tool} 並等待完成；只允許清單中的工具與參數（不接受 additional_args），目標依工具檢查格式；nmap、nuclei 的輸出解析為發現，其他工具的輸出記錄為單一 info 發現。wait 大於 0 時等待任務結束（受伺服器寫入逾時限制），結束時回傳 201，否則回傳 202 並可透過 GET /scans/{id} 查詢進度。HexStrike AI 連續失敗時回傳 503
+// @Tags integration
+// @Accept json
+// @Produce json
+// @Param scan body dto.HexStrikeScanRequest true "工具、目標與參數"
+// @Param wait query int false "等待掃描結束的秒數（最多 300）" default(0)
+// @Success 201 {object} vo.ScanJobDetailResponse
+// @Success 202 {object} vo.ScanJobDetailResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 503 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /integration/hexstrike/scan [post]
+func (h *HexStrikeHandler) CreateScan(c *gin.Context) {
+	var params dto.HexStrikeScanParams
+	var req dto.HexStrikeScanRequest
+
+	// 綁定查詢參數與請求
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	scan, done, err := h.service.CreateScan(c.Request.Context(), &req, time.Duration(params.Wait)*time.Second)
+	if err != nil {
+		c.Error(apperror.Internal("create_failed", err))
+		return
+	}
+
+	if !done {
+		c.JSON(http.StatusAccepted, scan)
+		return
+	}
+	c.JSON(http.StatusCreated, scan)
+}
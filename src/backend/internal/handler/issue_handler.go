@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// IssueHandler 跨掃描問題處理器
+type IssueHandler struct {
+	service *service.IssueService
+}
+
+// NewIssueHandler 建立新的 IssueHandler
+func NewIssueHandler(service *service.IssueService) *IssueHandler {
+	return &IssueHandler{service: service}
+}
+
+// GetIssues 取得問題列表
+// @Summary 取得問題列表
+// @Description 取得跨掃描去重後的問題（支援分頁與過濾），依最後發現時間由新到舊排序
+// @Tags issues
+// @Produce json
+// @Param page query int false "頁碼" default(1)
+// @Param page_size query int false "每頁數量" default(20)
+// @Param target query string false "掃描目標過濾"
+// @Param tool query string false "掃描工具過濾"
+// @Param status query string false "狀態過濾（open、resolved）"
+// @Param severity query string false "嚴重程度過濾"
+// @Param host query string false "主機過濾"
+// @Success 200 {object} vo.PaginatedResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /issues [get]
+func (h *IssueHandler) GetIssues(c *gin.Context) {
+	var params dto.IssueQueryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	issues, err := h.service.GetIssues(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, issues)
+}
+
+// GetIssue 取得問題詳情
+// @Summary 取得問題詳情
+// @Description 根據 ID 取得問題
+// @Tags issues
+// @Produce json
+// @Param id path int true "問題 ID"
+// @Success 200 {object} vo.IssueResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /issues/{id} [get]
+func (h *IssueHandler) GetIssue(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的問題 ID"))
+		return
+	}
+
+	// 呼叫 service
+	issue, err := h.service.GetIssueByID(uint(id))
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, issue)
+}
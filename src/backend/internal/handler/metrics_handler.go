@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandler 平台指標處理器
+type MetricsHandler struct {
+	service *service.MetricsService
+}
+
+// NewMetricsHandler 建立新的 MetricsHandler
+func NewMetricsHandler(service *service.MetricsService) *MetricsHandler {
+	return &MetricsHandler{service: service}
+}
+
+// GetSummary 取得平台指標摘要
+// @Summary 取得平台指標摘要
+// @Description 統計掃描任務（依狀態、類型）、發現（依嚴重程度）與安全事件；threats_blocked 為已解決的 intrusion、threat 事件數量。結果快取 30 秒，掃描結果寫入時失效
+// @Tags metrics
+// @Produce json
+// @Param from query string false "時間下限（RFC3339，含）；掃描與發現依掃描任務建立時間，事件依事件建立時間"
+// @Param to query string false "時間上限（RFC3339，不含）"
+// @Param by_target query bool false "附上依目標的統計"
+// @Param target_limit query int false "依目標統計的筆數上限" default(10)
+// @Success 200 {object} vo.MetricsResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /metrics/summary [get]
+func (h *MetricsHandler) GetSummary(c *gin.Context) {
+	var params dto.MetricsSummaryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	summary, err := h.service.GetSummary(c.Request.Context(), &params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetTrends 取得趨勢
+// @Summary 取得趨勢
+// @Description 依 day、week、month 區間（UTC）統計掃描開始/完成/失敗、各嚴重程度的新發現、期末開放的 critical/high 問題、已解決問題與平均修復時間（小時），以及安全事件的建立與解決數量
+// @Tags metrics
+// @Produce json
+// @Param interval query string false "區間粒度" Enums(day, week, month) default(day)
+// @Param from query string false "時間下限（RFC3339，對齊到所在區間起點；預設為最近 30 天、12 週或 12 個月）"
+// @Param to query string false "時間上限（RFC3339，不含；預設為現在）"
+// @Param target query string false "目標（套用於掃描、發現與問題）"
+// @Param scan_type query string false "掃描類型（套用於掃描、發現與問題）"
+// @Success 200 {object} vo.TrendResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /metrics/trends [get]
+func (h *MetricsHandler) GetTrends(c *gin.Context) {
+	var params dto.TrendQueryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	trends, err := h.service.GetTrends(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, trends)
+}
@@ -1,15 +1,21 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
 	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
 	"github.com/dennislwm/unified-security-platform/backend/internal/service"
 	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
 	"github.com/gin-gonic/gin"
 )
 
+// maxResultUploadBytes 上傳掃描結果的大小上限
+const maxResultUploadBytes = 100 << 20
+
 // ScanHandler 掃描處理器
 type ScanHandler struct {
 	service *service.ScanService
@@ -30,26 +36,24 @@ func NewScanHandler(service *service.ScanService) *ScanHandler {
 // @Success 201 {object} vo.ScanJobResponse
 // @Failure 400 {object} vo.ErrorResponse
 // @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
 // @Router /scans [post]
 func (h *ScanHandler) CreateScan(c *gin.Context) {
 	var req dto.CreateScanRequest
 
 	// 綁定並驗證請求
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, vo.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Validation("invalid_request", err.Error()))
 		return
 	}
 
 	// 呼叫 service
 	scan, err := h.service.CreateScan(&req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, vo.ErrorResponse{
-			Error:   "create_failed",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Internal("create_failed", err))
 		return
 	}
 
@@ -58,40 +62,44 @@ func (h *ScanHandler) CreateScan(c *gin.Context) {
 
 // GetScan 取得掃描任務詳情
 // @Summary 取得掃描任務詳情
-// @Description 根據 ID 取得掃描任務的詳細資訊（包含發現）
+// @Description 根據 ID 取得掃描任務的詳細資訊，包含發現統計與分頁後的發現（依嚴重程度、CVSS 由高到低）；summary=true 時只回傳統計
 // @Tags scans
 // @Produce json
 // @Param id path int true "掃描任務 ID"
+// @Param page query int false "發現頁碼" default(1)
+// @Param page_size query int false "每頁發現數量（最多 500）" default(100)
+// @Param severity query []string false "嚴重程度過濾（可逗號分隔或重複指定）" collectionFormat(multi)
+// @Param summary query bool false "只回傳各嚴重程度的統計"
 // @Success 200 {object} vo.ScanJobDetailResponse
+// @Failure 400 {object} vo.ErrorResponse
 // @Failure 404 {object} vo.ErrorResponse
 // @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
 // @Router /scans/{id} [get]
 func (h *ScanHandler) GetScan(c *gin.Context) {
 	// 解析 ID
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, vo.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "無效的掃描任務 ID",
-		})
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
+		return
+	}
+
+	var params dto.ScanDetailParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
 		return
 	}
 
 	// 呼叫 service
-	scan, err := h.service.GetScanByID(uint(id))
+	scan, err := h.service.GetScanByID(uint(id), &params)
 	if err != nil {
-		if err.Error() == "掃描任務不存在" {
-			c.JSON(http.StatusNotFound, vo.ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, vo.ErrorResponse{
-			Error:   "query_failed",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Internal("query_failed", err))
 		return
 	}
 
@@ -100,37 +108,52 @@ func (h *ScanHandler) GetScan(c *gin.Context) {
 
 // GetScans 取得掃描任務列表
 // @Summary 取得掃描任務列表
-// @Description 取得掃描任務列表（支援分頁和過濾）
+// @Description 取得掃描任務列表（支援分頁和過濾）；pagination=cursor 或帶 cursor 時改用 keyset 分頁並回傳 vo.CursorResponse
 // @Tags scans
 // @Produce json
 // @Param page query int false "頁碼" default(1)
 // @Param page_size query int false "每頁數量" default(10)
+// @Param pagination query string false "分頁方式" Enums(page, cursor)
+// @Param cursor query string false "上一頁回傳的 next_cursor"
+// @Param limit query int false "keyset 分頁每頁數量" default(10)
 // @Param status query string false "狀態過濾"
 // @Param scan_type query string false "類型過濾"
 // @Param target query string false "目標過濾"
+// @Param target_match query string false "目標比對方式" Enums(contains, prefix) default(contains)
+// @Param estimate query bool false "以查詢計畫估計總數取代精確計數"
 // @Success 200 {object} vo.PaginatedResponse
 // @Failure 400 {object} vo.ErrorResponse
 // @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
 // @Router /scans [get]
 func (h *ScanHandler) GetScans(c *gin.Context) {
 	var params dto.ScanQueryParams
 
 	// 綁定查詢參數
 	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, vo.ErrorResponse{
-			Error:   "invalid_params",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// keyset 分頁
+	if params.Pagination == "cursor" || params.Cursor != "" {
+		scans, err := h.service.GetScansByCursor(&params)
+		if err != nil {
+			c.Error(apperror.Internal("query_failed", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, scans)
 		return
 	}
 
 	// 呼叫 service
 	scans, err := h.service.GetScans(&params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, vo.ErrorResponse{
-			Error:   "query_failed",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Internal("query_failed", err))
 		return
 	}
 
@@ -139,98 +162,158 @@ func (h *ScanHandler) GetScans(c *gin.Context) {
 
 // UpdateScanStatus 更新掃描狀態
 // @Summary 更新掃描狀態
-// @Description 更新掃描任務的狀態
+// @Description 更新掃描任務的錯誤訊息，或將待執行、執行中的任務設為 cancelled（與 POST /scans/{id}/cancel 相同）；running、completed、failed 由背景 worker 設定，手動設定或不允許的轉換回傳 409
 // @Tags scans
 // @Accept json
 // @Produce json
 // @Param id path int true "掃描任務 ID"
 // @Param update body dto.UpdateScanRequest true "更新資訊"
-// @Success 200 {object} vo.SuccessResponse
+// @Success 200 {object} vo.ScanJobResponse
 // @Failure 400 {object} vo.ErrorResponse
 // @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
 // @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
 // @Router /scans/{id} [patch]
 func (h *ScanHandler) UpdateScanStatus(c *gin.Context) {
 	// 解析 ID
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, vo.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "無效的掃描任務 ID",
-		})
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
 		return
 	}
 
 	var req dto.UpdateScanRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, vo.ErrorResponse{
-			Error:   "invalid_request",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Validation("invalid_request", err.Error()))
 		return
 	}
 
 	// 更新狀態
-	if req.Status != nil {
-		if err := h.service.UpdateScanStatus(uint(id), *req.Status); err != nil {
-			if err.Error() == "掃描任務不存在" {
-				c.JSON(http.StatusNotFound, vo.ErrorResponse{
-					Error:   "not_found",
-					Message: err.Error(),
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, vo.ErrorResponse{
-				Error:   "update_failed",
-				Message: err.Error(),
-			})
-			return
-		}
+	scan, err := h.service.UpdateScanStatus(uint(id), &req)
+	if err != nil {
+		c.Error(apperror.Internal("update_failed", err))
+		return
 	}
 
-	c.JSON(http.StatusOK, vo.SuccessResponse{
-		Success: true,
-		Message: "掃描狀態已更新",
-	})
+	c.JSON(http.StatusOK, scan)
+}
+
+// DiffScan 比較兩次掃描
+// @Summary 比較兩次掃描
+// @Description 以發現指紋比較掃描任務與另一次掃描，回傳新增、已解決、未變更與嚴重程度改變的發現；未指定 against 時使用同一目標與掃描類型的前一次已完成掃描
+// @Tags scans
+// @Produce json
+// @Produce text/markdown
+// @Param id path int true "掃描任務 ID"
+// @Param against query int false "比較對象的掃描任務 ID（須為同一目標與掃描類型）"
+// @Param format query string false "輸出格式（json、markdown）" default(json)
+// @Success 200 {object} vo.ScanDiffResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /scans/{id}/diff [get]
+func (h *ScanHandler) DiffScan(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
+		return
+	}
+
+	var params dto.ScanDiffParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	diff, err := h.service.DiffScans(uint(id), params.Against)
+	if err != nil {
+		c.Error(apperror.Internal("diff_failed", err))
+		return
+	}
+
+	if params.Format == "markdown" {
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(service.DiffMarkdown(diff)))
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// RetryScan 重試掃描任務
+// @Summary 重試掃描任務
+// @Description 以失敗或已取消的掃描任務建立新的嘗試（新任務的 retry_of_id 指向原任務）
+// @Tags scans
+// @Produce json
+// @Param id path int true "掃描任務 ID"
+// @Success 201 {object} vo.ScanJobResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /scans/{id}/retry [post]
+func (h *ScanHandler) RetryScan(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
+		return
+	}
+
+	scan, err := h.service.RetryScan(uint(id))
+	if err != nil {
+		c.Error(apperror.Internal("retry_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, scan)
 }
 
 // DeleteScan 刪除掃描任務
 // @Summary 刪除掃描任務
-// @Description 軟刪除掃描任務
+// @Description 軟刪除已結束的掃描任務；待執行或執行中的任務需先取消，否則回傳 409
 // @Tags scans
 // @Produce json
 // @Param id path int true "掃描任務 ID"
 // @Success 200 {object} vo.SuccessResponse
 // @Failure 400 {object} vo.ErrorResponse
 // @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
 // @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
 // @Router /scans/{id} [delete]
 func (h *ScanHandler) DeleteScan(c *gin.Context) {
 	// 解析 ID
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, vo.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "無效的掃描任務 ID",
-		})
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
 		return
 	}
 
 	// 呼叫 service
 	if err := h.service.DeleteScan(uint(id)); err != nil {
-		if err.Error() == "掃描任務不存在" {
-			c.JSON(http.StatusNotFound, vo.ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, vo.ErrorResponse{
-			Error:   "delete_failed",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Internal("delete_failed", err))
 		return
 	}
 
@@ -240,6 +323,104 @@ func (h *ScanHandler) DeleteScan(c *gin.Context) {
 	})
 }
 
+// CancelScan 取消掃描任務
+// @Summary 取消掃描任務
+// @Description 取消待執行或執行中的掃描任務；執行中的工具程序會被中止，已收集的部分結果會保留
+// @Tags scans
+// @Produce json
+// @Param id path int true "掃描任務 ID"
+// @Success 200 {object} vo.ScanJobResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /scans/{id}/cancel [post]
+func (h *ScanHandler) CancelScan(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
+		return
+	}
+
+	// 呼叫 service
+	scan, err := h.service.CancelScan(uint(id))
+	if err != nil {
+		c.Error(apperror.Internal("cancel_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, scan)
+}
+
+// UploadResults 上傳掃描結果
+// @Summary 上傳掃描結果
+// @Description 解析工具輸出（例如 nuclei -jsonl）並附加到既有的掃描任務；multipart/form-data 使用欄位 file，其他 Content-Type 直接以 request body 上傳（上限 100 MiB，內容為空或超過上限回傳 400）；只能上傳到已結束或未執行中的 custom 掃描任務，否則回傳 409
+// @Tags scans
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param id path int true "掃描任務 ID"
+// @Param format query string false "結果格式（預設為掃描任務的 scan_type）"
+// @Param file formData file false "結果檔案"
+// @Success 201 {object} vo.ImportResultResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /scans/{id}/results [post]
+func (h *ScanHandler) UploadResults(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的掃描任務 ID"))
+		return
+	}
+
+	// 取得結果內容：multipart 只讀取 file 欄位，其他 Content-Type 直接讀取 body
+	// （不可對原始 body 呼叫 FormFile，否則 form-urlencoded 的 body 會被 ParseForm 讀完）
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxResultUploadBytes)
+	var body io.Reader = c.Request.Body
+	if c.ContentType() == "multipart/form-data" {
+		file, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.Error(service.ErrResultsTooLarge.WithDetails(map[string]interface{}{"max_bytes": tooLarge.Limit}))
+				return
+			}
+			c.Error(apperror.Validation("invalid_request", "無法讀取 multipart 欄位 file").Wrap(err))
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.Error(apperror.Validation("invalid_request", err.Error()))
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	// 呼叫 service
+	result, err := h.service.ImportResults(uint(id), c.Query("format"), body)
+	if err != nil {
+		c.Error(apperror.Internal("import_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
 // GetMetrics 取得掃描指標
 // @Summary 取得掃描統計指標
 // @Description 取得掃描任務的統計資訊
@@ -247,21 +428,17 @@ func (h *ScanHandler) DeleteScan(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} vo.MetricsResponse
 // @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
 // @Router /scans/metrics [get]
 func (h *ScanHandler) GetMetrics(c *gin.Context) {
 	metrics, err := h.service.GetMetrics()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, vo.ErrorResponse{
-			Error:   "query_failed",
-			Message: err.Error(),
-		})
+		c.Error(apperror.Internal("query_failed", err))
 		return
 	}
 
 	c.JSON(http.StatusOK, metrics)
 }
-
-
-
-
-
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityEventHandler 安全事件處理器
+type SecurityEventHandler struct {
+	service *service.SecurityEventService
+}
+
+// NewSecurityEventHandler 建立新的 SecurityEventHandler
+func NewSecurityEventHandler(service *service.SecurityEventService) *SecurityEventHandler {
+	return &SecurityEventHandler{service: service}
+}
+
+// GetEvents 取得安全事件列表
+// @Summary 取得安全事件列表
+// @Description 取得安全事件（支援分頁、類型、嚴重程度、狀態、指派對象與建立時間範圍過濾）
+// @Tags security-events
+// @Produce json
+// @Param page query int false "頁碼" default(1)
+// @Param page_size query int false "每頁數量" default(20)
+// @Param event_type query string false "事件類型過濾"
+// @Param severity query string false "嚴重程度過濾"
+// @Param status query string false "狀態過濾"
+// @Param assigned_to query string false "指派對象（使用者名稱）過濾"
+// @Param unassigned query bool false "只列出未指派的事件"
+// @Param from query string false "建立時間下限（RFC3339，含）"
+// @Param to query string false "建立時間上限（RFC3339，不含）"
+// @Success 200 {object} vo.PaginatedResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /security-events [get]
+func (h *SecurityEventHandler) GetEvents(c *gin.Context) {
+	var params dto.SecurityEventQueryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	events, err := h.service.GetEvents(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// CreateEvent 建立安全事件
+// @Summary 建立安全事件
+// @Description 建立一筆狀態為 open 的安全事件（供偵測來源或自動化代理回報）
+// @Tags security-events
+// @Accept json
+// @Produce json
+// @Param event body dto.CreateSecurityEventRequest true "安全事件資訊"
+// @Success 201 {object} vo.SecurityEventResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /security-events [post]
+func (h *SecurityEventHandler) CreateEvent(c *gin.Context) {
+	var req dto.CreateSecurityEventRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	event, err := h.service.CreateEvent(&req)
+	if err != nil {
+		c.Error(apperror.Internal("create_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, event)
+}
+
+// GetEvent 取得安全事件詳情
+// @Summary 取得安全事件詳情
+// @Description 根據 ID 取得安全事件
+// @Tags security-events
+// @Produce json
+// @Param id path int true "安全事件 ID"
+// @Success 200 {object} vo.SecurityEventResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /security-events/{id} [get]
+func (h *SecurityEventHandler) GetEvent(c *gin.Context) {
+	id, ok := eventID(c)
+	if !ok {
+		return
+	}
+
+	// 呼叫 service
+	event, err := h.service.GetEventByID(id)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// AssignEvent 指派安全事件
+// @Summary 指派安全事件
+// @Description 將安全事件指派給具備分流權限的使用者（使用者名稱或 Email）；assigned_to 為空字串時取消指派
+// @Tags security-events
+// @Accept json
+// @Produce json
+// @Param id path int true "安全事件 ID"
+// @Param assignment body dto.AssignSecurityEventRequest true "指派對象"
+// @Success 200 {object} vo.SecurityEventResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /security-events/{id}/assignee [put]
+func (h *SecurityEventHandler) AssignEvent(c *gin.Context) {
+	id, ok := eventID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AssignSecurityEventRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	event, err := h.service.AssignEvent(id, &req)
+	if err != nil {
+		c.Error(apperror.Internal("assign_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// UpdateEventStatus 變更安全事件狀態
+// @Summary 變更安全事件狀態
+// @Description 依狀態機變更安全事件狀態；結案（resolved、false_positive）時記錄 resolved_at，重新開啟時清除，不允許的轉換回傳 409
+// @Tags security-events
+// @Accept json
+// @Produce json
+// @Param id path int true "安全事件 ID"
+// @Param status body dto.UpdateSecurityEventStatusRequest true "新狀態"
+// @Success 200 {object} vo.SecurityEventResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /security-events/{id}/status [put]
+func (h *SecurityEventHandler) UpdateEventStatus(c *gin.Context) {
+	id, ok := eventID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSecurityEventStatusRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	event, err := h.service.UpdateEventStatus(id, &req)
+	if err != nil {
+		c.Error(apperror.Internal("update_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// GetMetrics 取得安全事件統計
+// @Summary 取得安全事件統計
+// @Description 依狀態與嚴重程度統計事件數量，並計算各嚴重程度已解決事件的平均解決時間
+// @Tags security-events
+// @Produce json
+// @Param from query string false "建立時間下限（RFC3339，含）"
+// @Param to query string false "建立時間上限（RFC3339，不含）"
+// @Success 200 {object} vo.SecurityEventMetricsResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /security-events/metrics [get]
+func (h *SecurityEventHandler) GetMetrics(c *gin.Context) {
+	var params dto.SecurityEventMetricsParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	metrics, err := h.service.GetMetrics(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// eventID 解析路徑中的安全事件 ID，無效時回報錯誤
+func eventID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的安全事件 ID"))
+		return 0, false
+	}
+	return uint(id), true
+}
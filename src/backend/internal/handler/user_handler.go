@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/middleware"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler 使用者處理器
+type UserHandler struct {
+	service *service.UserService
+}
+
+// NewUserHandler 建立新的 UserHandler
+func NewUserHandler(service *service.UserService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+// GetUsers 取得使用者列表
+// @Summary 取得使用者列表
+// @Description 取得使用者列表（支援分頁、角色與啟用狀態過濾）；僅限管理員
+// @Tags users
+// @Produce json
+// @Param page query int false "頁碼" default(1)
+// @Param page_size query int false "每頁數量" default(20)
+// @Param role query string false "角色過濾"
+// @Param is_active query bool false "啟用狀態過濾"
+// @Param q query string false "搜尋使用者名稱、Email、姓名"
+// @Success 200 {object} vo.PaginatedResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /users [get]
+func (h *UserHandler) GetUsers(c *gin.Context) {
+	var params dto.UserQueryParams
+
+	// 綁定查詢參數
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.Error(apperror.Validation("invalid_params", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	users, err := h.service.GetUsers(&params)
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// CreateUser 建立使用者
+// @Summary 建立使用者
+// @Description 建立新的使用者帳號（密碼需符合密碼政策）；僅限管理員
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body dto.CreateUserRequest true "使用者資訊"
+// @Success 201 {object} vo.UserResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req dto.CreateUserRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	user, err := h.service.CreateUser(&req)
+	if err != nil {
+		c.Error(apperror.Internal("create_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// GetUser 取得使用者詳情
+// @Summary 取得使用者詳情
+// @Description 根據 ID 取得使用者；僅限管理員
+// @Tags users
+// @Produce json
+// @Param id path int true "使用者 ID"
+// @Success 200 {object} vo.UserResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /users/{id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的使用者 ID"))
+		return
+	}
+
+	// 呼叫 service
+	user, err := h.service.GetUserByID(uint(id))
+	if err != nil {
+		c.Error(apperror.Internal("query_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateUser 更新使用者
+// @Summary 更新使用者
+// @Description 更新使用者的 Email、姓名、角色、啟用狀態或重設密碼；不可移除最後一位管理員；僅限管理員
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "使用者 ID"
+// @Param user body dto.UpdateUserRequest true "更新資訊"
+// @Success 200 {object} vo.UserResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /users/{id} [patch]
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的使用者 ID"))
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	user, err := h.service.UpdateUser(uint(id), &req)
+	if err != nil {
+		c.Error(apperror.Internal("update_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser 刪除使用者
+// @Summary 刪除使用者
+// @Description 軟刪除使用者；不可刪除自己或最後一位管理員；僅限管理員
+// @Tags users
+// @Produce json
+// @Param id path int true "使用者 ID"
+// @Success 200 {object} vo.SuccessResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 403 {object} vo.ErrorResponse
+// @Failure 404 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	// 解析 ID
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.Error(apperror.Validation("invalid_id", "無效的使用者 ID"))
+		return
+	}
+
+	// 呼叫 service
+	if err := h.service.DeleteUser(uint(id), middleware.CurrentUser(c).ID); err != nil {
+		c.Error(apperror.Internal("delete_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.SuccessResponse{
+		Success: true,
+		Message: "使用者已刪除",
+	})
+}
+
+// GetMe 取得目前使用者
+// @Summary 取得目前使用者
+// @Description 取得 access token 對應的使用者資訊
+// @Tags me
+// @Produce json
+// @Success 200 {object} vo.UserResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Security Bearer
+// @Security ApiKey
+// @Router /me [get]
+func (h *UserHandler) GetMe(c *gin.Context) {
+	c.JSON(http.StatusOK, vo.FromUser(middleware.CurrentUser(c)))
+}
+
+// UpdateMe 更新個人資料
+// @Summary 更新個人資料
+// @Description 更新目前使用者的 Email 與姓名
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param profile body dto.UpdateProfileRequest true "個人資料"
+// @Success 200 {object} vo.UserResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 409 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Router /me [patch]
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	user, err := h.service.UpdateProfile(middleware.CurrentUser(c), &req)
+	if err != nil {
+		c.Error(apperror.Internal("update_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangePassword 變更密碼
+// @Summary 變更密碼
+// @Description 驗證目前密碼後變更密碼；新密碼需符合密碼政策，變更前簽發的 token 隨即失效
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param password body dto.ChangePasswordRequest true "目前密碼與新密碼"
+// @Success 200 {object} vo.SuccessResponse
+// @Failure 400 {object} vo.ErrorResponse
+// @Failure 401 {object} vo.ErrorResponse
+// @Failure 500 {object} vo.ErrorResponse
+// @Security Bearer
+// @Router /me/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+
+	// 綁定並驗證請求
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	// 呼叫 service
+	if err := h.service.ChangePassword(middleware.CurrentUser(c), &req); err != nil {
+		c.Error(apperror.Internal("change_password_failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, vo.SuccessResponse{
+		Success: true,
+		Message: "密碼已變更，請重新登入",
+	})
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"github.com/dennislwm/unified-security-platform/backend/pkg/redis"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ScanCounter 提供掃描任務數量統計（由 repository.ScanRepository 實作）
+type ScanCounter interface {
+	CountByStatus() (map[string]int64, error)
+	CountByScanType() (map[string]int64, error)
+}
+
+// scanCollector 於每次抓取時從資料庫統計掃描任務數量
+type scanCollector struct {
+	scans    ScanCounter
+	byStatus *prometheus.Desc
+	byType   *prometheus.Desc
+}
+
+// newScanCollector 建立掃描任務數量收集器
+func newScanCollector(scans ScanCounter) *scanCollector {
+	return &scanCollector{
+		scans: scans,
+		byStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "scan_jobs"),
+			"掃描任務數量（依狀態）", []string{"status"}, nil),
+		byType: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "scan_jobs_by_type"),
+			"掃描任務數量（依掃描類型）", []string{"scan_type"}, nil),
+	}
+}
+
+// Describe 實作 prometheus.Collector
+func (c *scanCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.byStatus
+	ch <- c.byType
+}
+
+// Collect 實作 prometheus.Collector；查詢失敗時回報無效指標
+func (c *scanCollector) Collect(ch chan<- prometheus.Metric) {
+	if counts, err := c.scans.CountByStatus(); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.byStatus, err)
+	} else {
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(c.byStatus, prometheus.GaugeValue, float64(count), status)
+		}
+	}
+
+	if counts, err := c.scans.CountByScanType(); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.byType, err)
+	} else {
+		for scanType, count := range counts {
+			ch <- prometheus.MustNewConstMetric(c.byType, prometheus.GaugeValue, float64(count), scanType)
+		}
+	}
+}
+
+// redisCollector Redis 連線池統計
+type redisCollector struct {
+	client      *redis.Client
+	hits        *prometheus.Desc
+	misses      *prometheus.Desc
+	timeouts    *prometheus.Desc
+	staleConns  *prometheus.Desc
+	connections *prometheus.Desc
+}
+
+// newRedisCollector 建立 Redis 連線池收集器
+func newRedisCollector(client *redis.Client) *redisCollector {
+	name := func(metric string) string {
+		return prometheus.BuildFQName(namespace, "redis_pool", metric)
+	}
+	return &redisCollector{
+		client:      client,
+		hits:        prometheus.NewDesc(name("hits_total"), "連線池取得閒置連線的次數", nil, nil),
+		misses:      prometheus.NewDesc(name("misses_total"), "連線池沒有閒置連線而新建連線的次數", nil, nil),
+		timeouts:    prometheus.NewDesc(name("timeouts_total"), "等待連線逾時的次數", nil, nil),
+		staleConns:  prometheus.NewDesc(name("stale_connections_total"), "因過期而關閉的連線數量", nil, nil),
+		connections: prometheus.NewDesc(name("connections"), "連線池中的連線數量（依狀態）", []string{"state"}, nil),
+	}
+}
+
+// Describe 實作 prometheus.Collector
+func (c *redisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.staleConns
+	ch <- c.connections
+}
+
+// Collect 實作 prometheus.Collector
+func (c *redisCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+	ch <- prometheus.MustNewConstMetric(c.connections, prometheus.GaugeValue, float64(stats.IdleConns), "idle")
+	ch <- prometheus.MustNewConstMetric(c.connections, prometheus.GaugeValue, float64(stats.TotalConns-stats.IdleConns), "in_use")
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace 平台指標名稱前綴
+const namespace = "usp"
+
+// UnmatchedRoute 未對應到任何路由的請求使用的 route 標籤（避免以原始路徑產生大量標籤值）
+const UnmatchedRoute = "unmatched"
+
+// Registry Prometheus 指標登錄表：HTTP 請求、掃描任務、發現與連線池統計
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	findings     *prometheus.CounterVec
+	scanDuration *prometheus.HistogramVec
+}
+
+// NewRegistry 建立指標登錄表；scans、sqlDB、redisClient 為 nil 時略過對應的指標
+func NewRegistry(scans ScanCounter, sqlDB *sql.DB, redisClient *redis.Client) *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP 請求數量（依方法、路由與狀態碼）",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP 請求處理時間（秒）",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		findings: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scan_findings_total",
+			Help:      "寫入的掃描發現數量（依嚴重程度與掃描類型）",
+		}, []string{"severity", "scan_type"}),
+		scanDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "scan_duration_seconds",
+			Help:      "掃描任務執行時間（秒，依掃描類型與最終狀態）",
+			Buckets:   prometheus.ExponentialBuckets(10, 2.5, 9),
+		}, []string{"scan_type", "status"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.httpRequests,
+		r.httpDuration,
+		r.findings,
+		r.scanDuration,
+	)
+	if scans != nil {
+		r.registry.MustRegister(newScanCollector(scans))
+	}
+	if sqlDB != nil {
+		r.registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, "postgres"))
+	}
+	if redisClient != nil {
+		r.registry.MustRegister(newRedisCollector(redisClient))
+	}
+	return r
+}
+
+// Handler 輸出 Prometheus exposition format 的 HTTP handler
+// 個別指標收集失敗時仍輸出其他指標
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// ObserveRequest 記錄一次 HTTP 請求
+func (r *Registry) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	code := strconv.Itoa(status)
+	r.httpRequests.WithLabelValues(method, route, code).Inc()
+	r.httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveFindings 記錄掃描任務寫入的發現
+func (r *Registry) ObserveFindings(scan *model.ScanJob, findings []model.ScanFinding) {
+	for i := range findings {
+		r.findings.WithLabelValues(findings[i].Severity, scan.ScanType).Inc()
+	}
+}
+
+// ObserveScanFinished 記錄結束的掃描任務執行時間（沒有開始或結束時間時略過）
+func (r *Registry) ObserveScanFinished(scan *model.ScanJob) {
+	if scan.StartedAt == nil || scan.CompletedAt == nil {
+		return
+	}
+	r.scanDuration.WithLabelValues(scan.ScanType, scan.Status).Observe(scan.Duration().Seconds())
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader 傳遞 API 金鑰的標頭
+const APIKeyHeader = "X-API-Key"
+
+// context 中存放認證資訊的 key
+const (
+	userKey   = "auth.user"
+	claimsKey = "auth.claims"
+	apiKeyKey = "auth.api_key"
+)
+
+var (
+	// ErrMissingToken 請求未帶 Bearer token 或 API 金鑰
+	ErrMissingToken = apperror.Unauthorized("missing_token", "缺少 Authorization Bearer token 或 X-API-Key")
+	// ErrSessionRequired 操作需要以帳號登入的 session，不接受 API 金鑰
+	ErrSessionRequired = apperror.Forbidden("session_required", "此操作需以帳號登入，不可使用 API 金鑰")
+)
+
+// TokenAuthenticator 驗證 JWT access token（由 *service.AuthService 實作）
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, accessToken string) (*model.User, *auth.Claims, error)
+}
+
+// APIKeyAuthenticator 驗證 API 金鑰（由 *service.APIKeyService 實作）
+type APIKeyAuthenticator interface {
+	Authenticate(key string) (*model.User, *model.APIKey, error)
+}
+
+// Auth 認證中間件：驗證 Authorization: Bearer <JWT> 或 X-API-Key 並將使用者放入 context
+func Auth(authService TokenAuthenticator, apiKeyService APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := strings.TrimSpace(c.GetHeader(APIKeyHeader)); key != "" {
+			user, apiKey, err := apiKeyService.Authenticate(key)
+			if err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+
+			c.Set(userKey, user)
+			c.Set(apiKeyKey, apiKey)
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Error(ErrMissingToken)
+			c.Abort()
+			return
+		}
+
+		user, claims, err := authService.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(userKey, user)
+		c.Set(claimsKey, claims)
+		c.Next()
+	}
+}
+
+// CurrentUser 取得目前已認證的使用者（未經 Auth 中間件時回傳 nil）
+func CurrentUser(c *gin.Context) *model.User {
+	if v, ok := c.Get(userKey); ok {
+		if user, ok := v.(*model.User); ok {
+			return user
+		}
+	}
+	return nil
+}
+
+// CurrentClaims 取得目前請求的 token 內容（未經 Auth 中間件時回傳 nil）
+func CurrentClaims(c *gin.Context) *auth.Claims {
+	if v, ok := c.Get(claimsKey); ok {
+		if claims, ok := v.(*auth.Claims); ok {
+			return claims
+		}
+	}
+	return nil
+}
+
+// CurrentAPIKey 取得目前請求使用的 API 金鑰（以 JWT 認證時回傳 nil）
+func CurrentAPIKey(c *gin.Context) *model.APIKey {
+	if v, ok := c.Get(apiKeyKey); ok {
+		if key, ok := v.(*model.APIKey); ok {
+			return key
+		}
+	}
+	return nil
+}
+
+// bearerToken 從 Authorization 標頭取出 Bearer token
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
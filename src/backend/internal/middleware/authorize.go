@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrPermissionDenied 使用者角色不具備路由所需的權限
+var ErrPermissionDenied = apperror.Forbidden("permission_denied", "權限不足")
+
+// AuditRecorder 寫入稽核紀錄（由 *service.AuditService 實作）
+type AuditRecorder interface {
+	Record(user *model.User, action, outcome string, req service.AuditRequest, details map[string]interface{}) error
+}
+
+// Authorizer 授權中間件：依路由宣告的權限檢查使用者角色，拒絕時寫入稽核紀錄
+type Authorizer struct {
+	audit  AuditRecorder
+	logger *logger.Logger
+}
+
+// NewAuthorizer 建立新的 Authorizer
+func NewAuthorizer(audit AuditRecorder, logger *logger.Logger) *Authorizer {
+	return &Authorizer{
+		audit:  audit,
+		logger: logger.With("component", "authorizer"),
+	}
+}
+
+// Require 要求目前使用者具備指定權限（須放在 Auth 中間件之後）
+// 以 API 金鑰認證時，金鑰的 scopes 也必須包含該權限
+func (a *Authorizer) Require(permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		allowed := auth.Allowed(user, permission)
+		if apiKey := CurrentAPIKey(c); apiKey != nil {
+			allowed = allowed && auth.ScopeAllows(apiKey.ScopeList(), permission)
+		}
+		if allowed {
+			c.Next()
+			return
+		}
+
+		a.recordDenied(c, user, "permission_denied", permission)
+		c.Error(ErrPermissionDenied.WithDetails(map[string]interface{}{"required": permission}))
+		c.Abort()
+	}
+}
+
+// RequireSession 要求以 JWT 登入（拒絕 API 金鑰），用於登出、變更密碼、管理 API 金鑰等操作（須放在 Auth 中間件之後）
+func (a *Authorizer) RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if CurrentClaims(c) != nil {
+			c.Next()
+			return
+		}
+
+		a.recordDenied(c, CurrentUser(c), "session_required", "session")
+		c.Error(ErrSessionRequired)
+		c.Abort()
+	}
+}
+
+// recordDenied 記錄被拒絕的存取；action 為稽核動作，required 為路由要求的權限
+func (a *Authorizer) recordDenied(c *gin.Context, user *model.User, action string, required auth.Permission) {
+	req := service.AuditRequest{
+		Method:   c.Request.Method,
+		Path:     c.FullPath(),
+		ClientIP: c.ClientIP(),
+	}
+	details := map[string]interface{}{"required": required, "url": c.Request.URL.Path}
+
+	log := a.logger.With("method", req.Method, "path", req.Path, "required", required, "client_ip", req.ClientIP)
+	if user != nil {
+		log = log.With("user_id", user.ID, "role", user.Role)
+	}
+	if apiKey := CurrentAPIKey(c); apiKey != nil {
+		details["api_key_id"] = apiKey.ID
+		details["scopes"] = apiKey.ScopeList()
+		log = log.With("api_key_id", apiKey.ID)
+	}
+	log.Warn("⛔ 權限不足，拒絕存取", "action", action)
+
+	if err := a.audit.Record(user, action, model.AuditOutcomeDenied, req, details); err != nil {
+		log.Error("❌ 寫入稽核紀錄失敗", "error", err)
+	}
+}
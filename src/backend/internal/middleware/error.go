@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// internalErrorMessage 500 回應的訊息；原始錯誤只寫入日誌，避免洩漏 SQL 或內部路徑等細節
+const internalErrorMessage = "伺服器內部錯誤，請稍後再試"
+
+// ErrorHandler 錯誤轉換中間件：將 handler 以 c.Error 回報的錯誤轉換為 vo.ErrorResponse
+//
+// 領域錯誤依類別對應 HTTP 狀態碼（NotFound→404、Conflict→409、Validation→400、
+// Unauthorized→401、Forbidden→403），其他錯誤視為 500 並記錄日誌，回應只包含通用訊息。
+func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status, response := Render(err)
+		if status >= http.StatusInternalServerError {
+			log.Error("❌ 請求處理失敗",
+				"method", c.Request.Method, "path", c.FullPath(), "code", response.Code, "error", err)
+		}
+		c.AbortWithStatusJSON(status, response)
+	}
+}
+
+// Render 將錯誤轉換為 HTTP 狀態碼與錯誤回應；500 以通用訊息取代原始錯誤
+func Render(err error) (int, vo.ErrorResponse) {
+	response := vo.ErrorResponse{
+		Error:   "internal_error",
+		Code:    "internal_error",
+		Message: err.Error(),
+	}
+
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		response.Details = appErr.Details
+		if appErr.Code != "" {
+			response.Code = appErr.Code
+		}
+	}
+
+	kind := apperror.KindOf(err)
+	if kind != nil {
+		response.Error = kind.Error()
+	}
+
+	status := http.StatusInternalServerError
+	switch kind {
+	case apperror.ErrNotFound:
+		status = http.StatusNotFound
+	case apperror.ErrConflict:
+		status = http.StatusConflict
+	case apperror.ErrValidation:
+		status = http.StatusBadRequest
+	case apperror.ErrUnauthorized:
+		status = http.StatusUnauthorized
+	case apperror.ErrForbidden:
+		status = http.StatusForbidden
+	case apperror.ErrUnavailable:
+		status = http.StatusServiceUnavailable
+	default:
+		response.Message = internalErrorMessage
+		response.Details = nil
+	}
+
+	return status, response
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 指標中間件：依方法、路由樣式（c.FullPath）與最終狀態碼記錄請求數量與處理時間
+// 需註冊在 ErrorHandler 之前，才能取得錯誤轉換後的狀態碼
+func Metrics(registry *metrics.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		registry.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey API 金鑰模型（供 CI 與自動化代理使用，只儲存雜湊值）
+type APIKey struct {
+	ID         uint       `gorm:"primarykey" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	Name       string     `gorm:"not null;size:100" json:"name"`
+	Prefix     string     `gorm:"not null;size:20" json:"prefix"`
+	KeyHash    string     `gorm:"not null;size:64;uniqueIndex" json:"-"`
+	Scopes     string     `gorm:"type:text[];default:'{}'" json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// 關聯
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName 指定表名
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsRevoked 檢查金鑰是否已撤銷
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired 檢查金鑰是否已過期
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// ScopeList 取得金鑰的權限範圍（scopes 為固定識別字，不含需跳脫的字元）
+func (k *APIKey) ScopeList() []string {
+	literal := strings.Trim(strings.TrimSpace(k.Scopes), "{}")
+	if literal == "" {
+		return []string{}
+	}
+	scopes := strings.Split(literal, ",")
+	for i := range scopes {
+		scopes[i] = strings.Trim(scopes[i], `"`)
+	}
+	return scopes
+}
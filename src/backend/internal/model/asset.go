@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// Asset 資產模型（子網域列舉等偵察結果）
+type Asset struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	Hostname       string    `gorm:"not null;size:255;uniqueIndex" json:"hostname"`
+	Domain         string    `gorm:"size:255;index" json:"domain,omitempty"`
+	IPAddresses    string    `gorm:"type:text[];default:'{}'" json:"ip_addresses,omitempty"`
+	ASN            int       `gorm:"index" json:"asn,omitempty"`
+	ASNDescription string    `gorm:"size:255" json:"asn_description,omitempty"`
+	Source         string    `gorm:"not null;size:50" json:"source"`
+	Sources        string    `gorm:"type:text[];default:'{}'" json:"sources,omitempty"`
+	ScanJobID      uint      `gorm:"not null;index" json:"scan_job_id"`
+	LastScanJobID  uint      `gorm:"not null;index" json:"last_scan_job_id"`
+	FirstSeen      time.Time `gorm:"not null" json:"first_seen"`
+	LastSeen       time.Time `gorm:"not null;index" json:"last_seen"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// 關聯
+	ScanJob *ScanJob `gorm:"foreignKey:ScanJobID" json:"-"`
+}
+
+// TableName 指定表名
+func (Asset) TableName() string {
+	return "assets"
+}
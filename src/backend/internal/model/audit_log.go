@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// 稽核結果
+const (
+	AuditOutcomeAllowed = "allowed"
+	AuditOutcomeDenied  = "denied"
+)
+
+// AuditLog 稽核紀錄模型
+type AuditLog struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	UserID    *uint     `gorm:"index" json:"user_id,omitempty"`
+	Username  string    `gorm:"size:100" json:"username,omitempty"`
+	Role      string    `gorm:"size:50" json:"role,omitempty"`
+	Action    string    `gorm:"not null;size:100;index" json:"action"`
+	Outcome   string    `gorm:"not null;size:20;check:outcome IN ('allowed', 'denied')" json:"outcome"`
+	Method    string    `gorm:"size:10" json:"method,omitempty"`
+	Path      string    `gorm:"size:255" json:"path,omitempty"`
+	ClientIP  string    `gorm:"size:64" json:"client_ip,omitempty"`
+	Details   string    `gorm:"type:jsonb;default:'{}'" json:"details,omitempty"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
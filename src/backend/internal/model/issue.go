@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+)
+
+// 問題狀態
+const (
+	IssueStatusOpen     = "open"
+	IssueStatusResolved = "resolved"
+)
+
+// Issue 跨掃描去重後的問題模型；同一目標中相同指紋的掃描發現都連結到同一筆問題
+type Issue struct {
+	ID              uint       `gorm:"primarykey" json:"id"`
+	Fingerprint     string     `gorm:"uniqueIndex:idx_issues_target_fingerprint,priority:2;not null;size:64" json:"fingerprint"`
+	Tool            string     `gorm:"not null;size:50" json:"tool"`
+	Target          string     `gorm:"not null;size:255;index;uniqueIndex:idx_issues_target_fingerprint,priority:1" json:"target"`
+	Severity        string     `gorm:"not null;size:20;check:severity IN ('critical', 'high', 'medium', 'low', 'info')" json:"severity"`
+	Title           string     `gorm:"not null;size:255" json:"title"`
+	Host            string     `gorm:"size:255" json:"host,omitempty"`
+	Port            int        `json:"port,omitempty"`
+	Protocol        string     `gorm:"size:20" json:"protocol,omitempty"`
+	CVEID           string     `gorm:"size:50" json:"cve_id,omitempty"`
+	Status          string     `gorm:"not null;default:open;size:20;index;check:status IN ('open', 'resolved')" json:"status"`
+	FirstSeen       time.Time  `json:"first_seen"`
+	LastSeen        time.Time  `gorm:"index" json:"last_seen"`
+	OccurrenceCount int        `gorm:"not null;default:1" json:"occurrence_count"`
+	FirstScanJobID  uint       `json:"first_scan_job_id"`
+	LastScanJobID   uint       `json:"last_scan_job_id"`
+	ResolvedAt      *time.Time `gorm:"index" json:"resolved_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Issue) TableName() string {
+	return "issues"
+}
+
+// IsOpen 檢查問題是否仍存在
+func (i *Issue) IsOpen() bool {
+	return i.Status == IssueStatusOpen
+}
@@ -4,6 +4,15 @@ import (
 	"time"
 )
 
+// 發現分流狀態
+const (
+	TriageStatusOpen          = "open"
+	TriageStatusConfirmed     = "confirmed"
+	TriageStatusFalsePositive = "false_positive"
+	TriageStatusAcceptedRisk  = "accepted_risk"
+	TriageStatusFixed         = "fixed"
+)
+
 // ScanFinding 掃描發現模型
 type ScanFinding struct {
 	ID           uint      `gorm:"primarykey" json:"id"`
@@ -19,11 +28,24 @@ type ScanFinding struct {
 	CWEID        string    `gorm:"size:50" json:"cwe_id,omitempty"`
 	Evidence     string    `gorm:"type:jsonb;default:'{}'" json:"evidence,omitempty"`
 	Remediation  string    `gorm:"type:text" json:"remediation,omitempty"`
-	References   string    `gorm:"type:text[]" json:"references,omitempty"`
+	References   string    `gorm:"type:text[];default:'{}'" json:"references,omitempty"`
 	DiscoveredAt time.Time `gorm:"index" json:"discovered_at"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
+	// 分流：accepted_risk 需有到期時間；CarriedFromID 指向沿用分流結果的先前發現
+	TriageStatus      string     `gorm:"not null;default:open;size:20;index;check:triage_status IN ('open', 'confirmed', 'false_positive', 'accepted_risk', 'fixed')" json:"triage_status"`
+	Assignee          string     `gorm:"size:100;index" json:"assignee,omitempty"`
+	Justification     string     `gorm:"type:text" json:"justification,omitempty"`
+	RiskAcceptedUntil *time.Time `json:"risk_accepted_until,omitempty"`
+	TriagedBy         string     `gorm:"size:100" json:"triaged_by,omitempty"`
+	TriagedAt         *time.Time `json:"triaged_at,omitempty"`
+	CarriedFromID     *uint      `json:"carried_from_id,omitempty"`
+
+	// 跨掃描去重：Fingerprint 由 parser.Fingerprint 計算，IssueID 指向對應的問題
+	Fingerprint string `gorm:"size:64;index" json:"fingerprint,omitempty"`
+	IssueID     *uint  `gorm:"index" json:"issue_id,omitempty"`
+
 	// 關聯
 	ScanJob *ScanJob `gorm:"foreignKey:ScanJobID" json:"-"`
 }
@@ -43,9 +65,26 @@ func (f *ScanFinding) IsHighRisk() bool {
 	return f.Severity == "critical" || f.Severity == "high"
 }
 
+// IsSuppressed 判斷發現是否被抑制（誤報，或風險接受尚未到期）
+func (f *ScanFinding) IsSuppressed(now time.Time) bool {
+	switch f.TriageStatus {
+	case TriageStatusFalsePositive:
+		return true
+	case TriageStatusAcceptedRisk:
+		return f.RiskAcceptedUntil != nil && f.RiskAcceptedUntil.After(now)
+	default:
+		return false
+	}
+}
+
 // SeverityScore 取得嚴重性分數（用於排序）
 func (f *ScanFinding) SeverityScore() int {
-	switch f.Severity {
+	return SeverityScore(f.Severity)
+}
+
+// SeverityScore 將嚴重程度轉為分數（critical 5 至 info 1，未知為 0）
+func SeverityScore(severity string) int {
+	switch severity {
 	case "critical":
 		return 5
 	case "high":
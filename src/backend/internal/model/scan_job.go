@@ -1,21 +1,52 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// 掃描任務狀態
+const (
+	ScanStatusPending   = "pending"
+	ScanStatusRunning   = "running"
+	ScanStatusCompleted = "completed"
+	ScanStatusFailed    = "failed"
+	ScanStatusCancelled = "cancelled"
+)
+
+// ScanTypeCustom 自訂掃描類型；沒有對應的掃描工具，結果由使用者上傳
+const ScanTypeCustom = "custom"
+
+// ScanTypeHexStrike 交由 HexStrike AI 執行工具的掃描類型；實際工具與參數記錄於 metadata
+const ScanTypeHexStrike = "hexstrike"
+
+// HexStrikeMetadata HexStrike 掃描任務的 metadata
+type HexStrikeMetadata struct {
+	Tool   string                 `json:"tool"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// scanTransitions 掃描任務允許的狀態轉換
+// pending → running → completed | failed | cancelled；結束狀態不可再變更，重試會建立新的任務
+var scanTransitions = map[string][]string{
+	ScanStatusPending: {ScanStatusRunning, ScanStatusCancelled},
+	ScanStatusRunning: {ScanStatusCompleted, ScanStatusFailed, ScanStatusCancelled},
+}
+
 // ScanJob 掃描任務模型
 type ScanJob struct {
 	ID           uint           `gorm:"primarykey" json:"id"`
 	Target       string         `gorm:"not null;size:255" json:"target"`
-	ScanType     string         `gorm:"not null;size:50;check:scan_type IN ('nuclei', 'nmap', 'amass', 'custom')" json:"scan_type"`
+	ScanType     string         `gorm:"not null;size:50;check:scan_type IN ('nuclei', 'nmap', 'amass', 'custom', 'hexstrike')" json:"scan_type"`
 	Status       string         `gorm:"default:pending;size:50;check:status IN ('pending', 'running', 'completed', 'failed', 'cancelled')" json:"status"`
-	StartedAt    *time.Time     `json:"started_at,omitempty"`
-	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+	StartedAt    *time.Time     `gorm:"index" json:"started_at,omitempty"`
+	CompletedAt  *time.Time     `gorm:"index" json:"completed_at,omitempty"`
 	ErrorMessage string         `gorm:"type:text" json:"error_message,omitempty"`
 	Metadata     string         `gorm:"type:jsonb;default:'{}'" json:"metadata,omitempty"`
+	RetryOfID    *uint          `gorm:"index" json:"retry_of_id,omitempty"`
+	Attempt      int            `gorm:"not null;default:1" json:"attempt"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -37,17 +68,38 @@ func (s *ScanJob) BeforeCreate(tx *gorm.DB) error {
 
 // IsCompleted 檢查掃描是否已完成
 func (s *ScanJob) IsCompleted() bool {
-	return s.Status == "completed"
+	return s.Status == ScanStatusCompleted
 }
 
 // IsFailed 檢查掃描是否失敗
 func (s *ScanJob) IsFailed() bool {
-	return s.Status == "failed"
+	return s.Status == ScanStatusFailed
 }
 
 // IsRunning 檢查掃描是否正在執行
 func (s *ScanJob) IsRunning() bool {
-	return s.Status == "running"
+	return s.Status == ScanStatusRunning
+}
+
+// IsTerminal 檢查掃描是否已結束（完成、失敗或取消）
+func (s *ScanJob) IsTerminal() bool {
+	_, ok := scanTransitions[s.Status]
+	return !ok
+}
+
+// CanTransitionTo 檢查是否允許從目前狀態轉換為 status
+func (s *ScanJob) CanTransitionTo(status string) bool {
+	for _, next := range scanTransitions[s.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRetry 檢查掃描是否可重試（僅失敗或取消的任務）
+func (s *ScanJob) CanRetry() bool {
+	return s.Status == ScanStatusFailed || s.Status == ScanStatusCancelled
 }
 
 // Duration 計算掃描執行時間
@@ -58,6 +110,27 @@ func (s *ScanJob) Duration() time.Duration {
 	return s.CompletedAt.Sub(*s.StartedAt)
 }
 
+// HexStrike 解析 HexStrike 掃描任務的 metadata
+func (s *ScanJob) HexStrike() (*HexStrikeMetadata, error) {
+	var metadata HexStrikeMetadata
+	if err := json.Unmarshal([]byte(s.Metadata), &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// Tool 產生結果的工具：HexStrike 任務為 metadata 中的工具，其餘為 scan_type
+// 用於發現指紋與問題歸屬，讓 HexStrike 執行的 nmap 與本機 nmap 的結果可互相比對
+func (s *ScanJob) Tool() string {
+	if s.ScanType != ScanTypeHexStrike {
+		return s.ScanType
+	}
+	if metadata, err := s.HexStrike(); err == nil && metadata.Tool != "" {
+		return metadata.Tool
+	}
+	return s.ScanType
+}
+
 
 
 
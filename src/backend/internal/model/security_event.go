@@ -4,18 +4,35 @@ import (
 	"time"
 )
 
+// 安全事件狀態
+const (
+	EventStatusOpen          = "open"
+	EventStatusInvestigating = "investigating"
+	EventStatusResolved      = "resolved"
+	EventStatusFalsePositive = "false_positive"
+)
+
+// eventTransitions 安全事件允許的狀態轉換
+// open → investigating → resolved | false_positive；已結案的事件可重新開啟
+var eventTransitions = map[string][]string{
+	EventStatusOpen:          {EventStatusInvestigating, EventStatusResolved, EventStatusFalsePositive},
+	EventStatusInvestigating: {EventStatusOpen, EventStatusResolved, EventStatusFalsePositive},
+	EventStatusResolved:      {EventStatusOpen},
+	EventStatusFalsePositive: {EventStatusOpen},
+}
+
 // SecurityEvent 安全事件模型
 type SecurityEvent struct {
 	ID          uint       `gorm:"primarykey" json:"id"`
 	EventType   string     `gorm:"not null;size:50;check:event_type IN ('intrusion', 'anomaly', 'threat', 'alert', 'incident')" json:"event_type"`
-	Severity    string     `gorm:"not null;size:20;check:severity IN ('critical', 'high', 'medium', 'low', 'info')" json:"severity"`
+	Severity    string     `gorm:"not null;size:20;check:severity IN ('critical', 'high', 'medium', 'low', 'info');index" json:"severity"`
 	Source      string     `gorm:"size:255" json:"source,omitempty"`
 	Destination string     `gorm:"size:255" json:"destination,omitempty"`
 	Description string     `gorm:"type:text;not null" json:"description"`
 	Details     string     `gorm:"type:jsonb;default:'{}'" json:"details,omitempty"`
-	Status      string     `gorm:"default:open;size:50;check:status IN ('open', 'investigating', 'resolved', 'false_positive')" json:"status"`
-	AssignedTo  string     `gorm:"size:100" json:"assigned_to,omitempty"`
-	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	Status      string     `gorm:"default:open;size:50;check:status IN ('open', 'investigating', 'resolved', 'false_positive');index" json:"status"`
+	AssignedTo  string     `gorm:"size:100;index" json:"assigned_to,omitempty"`
+	ResolvedAt  *time.Time `gorm:"index" json:"resolved_at,omitempty"`
 	CreatedAt   time.Time  `gorm:"index" json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
@@ -35,6 +52,21 @@ func (e *SecurityEvent) IsResolved() bool {
 	return e.Status == "resolved"
 }
 
+// IsClosed 檢查事件是否已結案（已解決或誤報）
+func (e *SecurityEvent) IsClosed() bool {
+	return e.Status == EventStatusResolved || e.Status == EventStatusFalsePositive
+}
+
+// CanTransitionTo 檢查是否允許從目前狀態轉換為 status
+func (e *SecurityEvent) CanTransitionTo(status string) bool {
+	for _, next := range eventTransitions[e.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
 // IsCritical 判斷是否為高危事件
 func (e *SecurityEvent) IsCritical() bool {
 	return e.Severity == "critical"
@@ -19,6 +19,9 @@ type User struct {
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// PasswordChangedAt 最後變更密碼時間，之前簽發的 token 一律失效
+	PasswordChangedAt *time.Time `json:"password_changed_at,omitempty"`
 }
 
 // TableName 指定表名
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// amassResult amass enum -json 輸出的單筆結果
+type amassResult struct {
+	Name      string         `json:"name"`
+	Domain    string         `json:"domain"`
+	Addresses []amassAddress `json:"addresses"`
+	Tag       string         `json:"tag"`
+	Sources   []string       `json:"sources"`
+	Source    string         `json:"source"`
+}
+
+// amassAddress amass 解析出的位址資訊
+type amassAddress struct {
+	IP   string `json:"ip"`
+	CIDR string `json:"cidr"`
+	ASN  int    `json:"asn"`
+	Desc string `json:"desc"`
+}
+
+// ParseAmass 解析 amass enum -json 輸出（每行一筆 JSON）
+//
+// 也接受每行一個主機名稱的純文字輸出（amass enum -o）。
+// 同一主機名稱出現多次時會合併其 IP 與資料來源。
+func ParseAmass(r io.Reader) ([]model.Asset, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	byName := make(map[string]*model.Asset)
+	ips := make(map[string][]string)
+	sources := make(map[string][]string)
+	order := make([]string, 0)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		var result amassResult
+		if line[0] == '{' {
+			if err := json.Unmarshal(line, &result); err != nil {
+				return nil, fmt.Errorf("第 %d 行不是有效的 amass JSON: %w", lineNo, err)
+			}
+		} else {
+			result.Name = string(line)
+			if fields := strings.Fields(result.Name); len(fields) > 0 {
+				result.Name = fields[0]
+			}
+		}
+
+		name := normalizeHostname(result.Name)
+		if name == "" {
+			return nil, fmt.Errorf("第 %d 行缺少主機名稱", lineNo)
+		}
+
+		asset, ok := byName[name]
+		if !ok {
+			asset = &model.Asset{
+				Hostname: truncate(name, 255),
+				Domain:   truncate(normalizeHostname(result.Domain), 255),
+				Source:   "amass",
+			}
+			byName[name] = asset
+			order = append(order, name)
+		}
+
+		for _, addr := range result.Addresses {
+			if ip := net.ParseIP(strings.TrimSpace(addr.IP)); ip != nil {
+				ips[name] = append(ips[name], ip.String())
+			}
+			if asset.ASN == 0 && addr.ASN > 0 {
+				asset.ASN = addr.ASN
+				asset.ASNDescription = truncate(addr.Desc, 255)
+			}
+		}
+		sources[name] = append(sources[name], result.Sources...)
+		if result.Source != "" {
+			sources[name] = append(sources[name], result.Source)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("讀取 amass 輸出失敗: %w", err)
+	}
+
+	assets := make([]model.Asset, 0, len(order))
+	for _, name := range order {
+		asset := byName[name]
+		if v := unique(ips[name]); len(v) > 0 {
+			asset.IPAddresses = textArray(v)
+		}
+		if v := unique(sources[name]); len(v) > 0 {
+			asset.Sources = textArray(v)
+		}
+		assets = append(assets, *asset)
+	}
+
+	return assets, nil
+}
+
+// normalizeHostname 將主機名稱轉為小寫並移除結尾的點
+func normalizeHostname(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+// unique 排序並去除重複值
+func unique(values []string) []string {
+	values = compact(values)
+	sort.Strings(values)
+	result := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAmass(t *testing.T) {
+	type wantAsset struct {
+		hostname, domain, ips, sources string
+		asn                            int
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		want    []wantAsset
+	}{
+		{
+			// 同一主機名稱（大小寫、結尾的點不同）合併 IP 與資料來源，ASN 取第一筆
+			name:    "json",
+			fixture: "amass.jsonl",
+			want: []wantAsset{
+				{"www.example.com", "example.com", `{"93.184.216.34","93.184.216.35"}`, `{"Crtsh","DNS","HackerTarget"}`, 15133},
+				{"api.example.com", "example.com", `{"2606:2800:220:1:248:1893:25c8:1946"}`, `{"CertSpotter"}`, 0},
+			},
+		},
+		{
+			// 純文字輸出取每行第一個欄位，略過註解
+			name:    "plain",
+			fixture: "amass.txt",
+			want: []wantAsset{
+				{"mail.example.com", "", "", "", 0},
+				{"dev.example.com", "", "", "", 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		assets, err := ParseAmass(strings.NewReader(readFixture(t, tt.fixture)))
+		if err != nil {
+			t.Errorf("%s: ParseAmass: %v", tt.name, err)
+			continue
+		}
+		if len(assets) != len(tt.want) {
+			t.Errorf("%s: assets = %d, want %d", tt.name, len(assets), len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			a := assets[i]
+			if a.Hostname != want.hostname || a.Domain != want.domain || a.IPAddresses != want.ips ||
+				a.Sources != want.sources || a.ASN != want.asn || a.Source != "amass" {
+				t.Errorf("%s: asset %d = {%s %s %s %s %d %s}, want %+v",
+					tt.name, i, a.Hostname, a.Domain, a.IPAddresses, a.Sources, a.ASN, a.Source, want)
+			}
+		}
+	}
+}
+
+func TestParseAmassErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"invalid json", "www.example.com\n{\"name\":", "第 2 行不是有效的 amass JSON"},
+		{"missing name", `{"domain":"example.com"}`, "第 1 行缺少主機名稱"},
+		{"dot only", ".", "第 1 行缺少主機名稱"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseAmass(strings.NewReader(tt.input)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	assets, err := ParseAmass(strings.NewReader("\n# only comments\n"))
+	if err != nil || len(assets) != 0 {
+		t.Errorf("comments only = %d assets, %v; want none", len(assets), err)
+	}
+}
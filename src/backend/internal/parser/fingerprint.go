@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// fingerprintEvidence 計算指紋時需要的證據欄位（nuclei 模板與 nmap 主機清單）
+type fingerprintEvidence struct {
+	TemplateID  string `json:"template_id"`
+	MatcherName string `json:"matcher_name"`
+	MatchedAt   string `json:"matched_at"`
+	Type        string `json:"type"`
+}
+
+// Fingerprint 計算跨掃描穩定的發現指紋（SHA-256 十六進位）
+//
+// 由工具、規則、主機、埠號、協定與正規化路徑組成；規則依序取 nuclei 模板（含 matcher）、nmap 開放埠、
+// CVE、nmap 主機清單，最後才使用標題，避免服務版本等會變動的文字影響指紋。
+// nmap 開放埠的代表 CVE 會隨 vulners 資料庫或服務版本改變，因此只以埠號識別，CVE 僅保留在證據中。
+func Fingerprint(tool string, finding *model.ScanFinding) string {
+	var evidence fingerprintEvidence
+	if finding.Evidence != "" {
+		_ = json.Unmarshal([]byte(finding.Evidence), &evidence)
+	}
+
+	var rule, location string
+	switch {
+	case evidence.TemplateID != "":
+		rule = "template:" + evidence.TemplateID
+		if evidence.MatcherName != "" {
+			rule += ":" + evidence.MatcherName
+		}
+		location = normalizePath(evidence.MatchedAt)
+	case tool == "nmap" && finding.Port > 0:
+		rule = "port"
+	case finding.CVEID != "":
+		rule = "cve:" + strings.ToUpper(finding.CVEID)
+	case evidence.Type == "host":
+		rule = "host"
+	default:
+		rule = "title:" + strings.ToLower(strings.Join(strings.Fields(finding.Title), " "))
+	}
+
+	parts := []string{
+		strings.ToLower(tool),
+		rule,
+		strings.ToLower(strings.TrimSpace(finding.Host)),
+		strconv.Itoa(finding.Port),
+		strings.ToLower(finding.Protocol),
+		location,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizePath 取出 URL 的路徑並正規化（去除查詢字串、片段、重複與結尾斜線）；非 URL 時回傳空字串
+func normalizePath(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	p := path.Clean("/" + u.Path)
+	if p == "/" {
+		return p
+	}
+	return strings.TrimSuffix(p, "/")
+}
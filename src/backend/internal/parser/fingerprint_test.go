@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// 指紋變動會讓既有問題被標記為已解決並建立新的問題，規則調整時需確認這些值的變化是預期的
+func TestFingerprintRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    string
+		finding model.ScanFinding
+		want    string
+	}{
+		{
+			name: "nuclei template with matcher",
+			tool: "nuclei",
+			finding: model.ScanFinding{
+				Host:     "example.com",
+				Port:     443,
+				CVEID:    "CVE-2021-41773",
+				Evidence: `{"template_id":"git-config","matcher_name":"default","matched_at":"https://example.com/admin//login/?next=1#top"}`,
+			},
+			want: "96f48e012430c54559893443825e951b0b495cbbd206b366965c6735a137666e",
+		},
+		{
+			name: "nmap open port with CVE",
+			tool: "nmap",
+			finding: model.ScanFinding{
+				Title:    "CVE-2021-41773 - 80/tcp http Apache httpd 2.4.49",
+				Host:     "10.0.0.5",
+				Port:     80,
+				Protocol: "tcp",
+				CVEID:    "CVE-2021-41773",
+			},
+			want: "5072e82bf9735f4ed6eadba17378c452f39f5f19a69e60b11c77a4e9d6b12bc1",
+		},
+		{
+			name:    "cve",
+			tool:    "custom",
+			finding: model.ScanFinding{Title: "Log4Shell", Host: "example.com", CVEID: "cve-2021-44228"},
+			want:    "9073d140c5e7199dfff1a3191f468e2a6564e6bc94d4e93d226fcb51737e3e1d",
+		},
+		{
+			name:    "nmap host inventory",
+			tool:    "nmap",
+			finding: model.ScanFinding{Title: "主機存活: 10.0.0.5", Host: "10.0.0.5", Evidence: `{"type":"host"}`},
+			want:    "dd95e7dc1ac61db11647b29333b2892dc44182e054e41cee9d5f8e102f4a01d0",
+		},
+		{
+			name:    "title",
+			tool:    "custom",
+			finding: model.ScanFinding{Title: "  Weak   TLS Cipher ", Host: "Example.com"},
+			want:    "46033e4b3d3b4cd3956ab45193d09a2e9da139434aa8d0dd3551556fc2cecd60",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Fingerprint(tt.tool, &tt.finding); got != tt.want {
+			t.Errorf("%s: Fingerprint = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprintStability(t *testing.T) {
+	nmapPort := func(cve, title string) model.ScanFinding {
+		return model.ScanFinding{Title: title, Host: "10.0.0.5", Port: 80, Protocol: "tcp", CVEID: cve}
+	}
+	nucleiAt := func(host, matcher, matchedAt string) model.ScanFinding {
+		return model.ScanFinding{
+			Host:     host,
+			Evidence: `{"template_id":"git-config","matcher_name":"` + matcher + `","matched_at":"` + matchedAt + `"}`,
+		}
+	}
+
+	tests := []struct {
+		name string
+		tool string
+		a, b model.ScanFinding
+		same bool
+	}{
+		{"nmap top CVE changes", "nmap",
+			nmapPort("CVE-2021-41773", "CVE-2021-41773 - 80/tcp http Apache httpd 2.4.49"),
+			nmapPort("CVE-2021-42013", "CVE-2021-42013 - 80/tcp http Apache httpd 2.4.50"), true},
+		{"nmap port gains a CVE", "nmap",
+			nmapPort("", "開放埠 80/tcp http"),
+			nmapPort("CVE-2021-41773", "CVE-2021-41773 - 80/tcp http Apache httpd 2.4.49"), true},
+		{"nmap different port", "nmap",
+			nmapPort("", "開放埠 80/tcp http"),
+			model.ScanFinding{Title: "開放埠 80/tcp http", Host: "10.0.0.5", Port: 8080, Protocol: "tcp"}, false},
+		{"nuclei query string and trailing slash", "nuclei",
+			nucleiAt("example.com", "default", "https://example.com/.git/config?x=1"),
+			nucleiAt("example.com", "default", "https://example.com/.git/config/"), true},
+		{"nuclei different matcher", "nuclei",
+			nucleiAt("example.com", "default", "https://example.com/.git/config"),
+			nucleiAt("example.com", "other", "https://example.com/.git/config"), false},
+		{"nuclei host case", "nuclei",
+			nucleiAt("Example.com", "default", "https://example.com/.git/config"),
+			nucleiAt("example.com", "default", "https://example.com/.git/config"), true},
+		{"cve case", "custom",
+			model.ScanFinding{Title: "a", CVEID: "cve-2021-44228"},
+			model.ScanFinding{Title: "b", CVEID: "CVE-2021-44228"}, true},
+		{"title on another host", "custom",
+			model.ScanFinding{Title: "Weak TLS Cipher"},
+			model.ScanFinding{Title: "Weak TLS Cipher", Host: "example.com"}, false},
+	}
+
+	for _, tt := range tests {
+		a, b := Fingerprint(tt.tool, &tt.a), Fingerprint(tt.tool, &tt.b)
+		if (a == b) != tt.same {
+			t.Errorf("%s: fingerprints equal = %v, want %v", tt.name, a == b, tt.same)
+		}
+	}
+}
@@ -0,0 +1,456 @@
+package parser
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// cvePattern 比對 CVE 編號
+var cvePattern = regexp.MustCompile(`CVE-\d{4}-\d{4,}`)
+
+// vulnersLinePattern 比對舊版 vulners 純文字輸出：CVE-2021-41773  7.5  https://...
+var vulnersLinePattern = regexp.MustCompile(`(CVE-\d{4}-\d{4,})\s+(\d+(?:\.\d+)?)`)
+
+// nmapHost nmap -oX 輸出中的單一主機
+type nmapHost struct {
+	EndTime   int64          `xml:"endtime,attr"`
+	Status    nmapStatus     `xml:"status"`
+	Addresses []nmapAddress  `xml:"address"`
+	Hostnames []nmapHostname `xml:"hostnames>hostname"`
+	Ports     []nmapPort     `xml:"ports>port"`
+	OSMatches []nmapOSMatch  `xml:"os>osmatch"`
+	Scripts   []nmapScript   `xml:"hostscript>script"`
+}
+
+type nmapStatus struct {
+	State  string `xml:"state,attr"`
+	Reason string `xml:"reason,attr"`
+}
+
+type nmapAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"`
+	Vendor   string `xml:"vendor,attr"`
+}
+
+type nmapHostname struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type nmapOSMatch struct {
+	Name     string `xml:"name,attr"`
+	Accuracy int    `xml:"accuracy,attr"`
+}
+
+type nmapPort struct {
+	Protocol string       `xml:"protocol,attr"`
+	PortID   int          `xml:"portid,attr"`
+	State    nmapStatus   `xml:"state"`
+	Service  nmapService  `xml:"service"`
+	Scripts  []nmapScript `xml:"script"`
+}
+
+type nmapService struct {
+	Name      string   `xml:"name,attr"`
+	Product   string   `xml:"product,attr"`
+	Version   string   `xml:"version,attr"`
+	ExtraInfo string   `xml:"extrainfo,attr"`
+	Tunnel    string   `xml:"tunnel,attr"`
+	CPEs      []string `xml:"cpe"`
+}
+
+type nmapScript struct {
+	ID     string      `xml:"id,attr"`
+	Output string      `xml:"output,attr"`
+	Tables []nmapTable `xml:"table"`
+	Elems  []nmapElem  `xml:"elem"`
+}
+
+type nmapTable struct {
+	Key    string      `xml:"key,attr"`
+	Tables []nmapTable `xml:"table"`
+	Elems  []nmapElem  `xml:"elem"`
+}
+
+type nmapElem struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// nmapVuln NSE 腳本回報的單一弱點
+type nmapVuln struct {
+	CVEID     string   `json:"cve_id"`
+	CVSSScore *float64 `json:"cvss_score,omitempty"`
+}
+
+// nmapPortEvidence 寫入每個開放埠 ScanFinding.Evidence 的內容
+type nmapPortEvidence struct {
+	Hostnames []string          `json:"hostnames,omitempty"`
+	State     string            `json:"state"`
+	Reason    string            `json:"reason,omitempty"`
+	Service   string            `json:"service,omitempty"`
+	Product   string            `json:"product,omitempty"`
+	Version   string            `json:"version,omitempty"`
+	ExtraInfo string            `json:"extra_info,omitempty"`
+	Tunnel    string            `json:"tunnel,omitempty"`
+	CPEs      []string          `json:"cpe,omitempty"`
+	Scripts   map[string]string `json:"scripts,omitempty"`
+	Vulns     []nmapVuln        `json:"vulns,omitempty"`
+}
+
+// nmapHostEvidence 寫入主機清單 ScanFinding.Evidence 的內容
+type nmapHostEvidence struct {
+	Type       string            `json:"type"`
+	Addresses  []string          `json:"addresses"`
+	MAC        string            `json:"mac,omitempty"`
+	MACVendor  string            `json:"mac_vendor,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	OS         string            `json:"os,omitempty"`
+	OSAccuracy int               `json:"os_accuracy,omitempty"`
+	OpenPorts  []string          `json:"open_ports"`
+	Scripts    map[string]string `json:"scripts,omitempty"`
+}
+
+// ParseNmap 解析 nmap -oX 輸出
+//
+// 每個存活主機產生一筆 info 等級的主機清單發現，每個開放埠產生一筆發現；
+// vulners / vuln 類 NSE 腳本回報的 CVE 會對應到 CVEID 與 CVSSScore。
+// 未存活（down）的主機不產生發現；輸出在中途被截斷（掃描被取消）時回傳已解析的部分。
+func ParseNmap(r io.Reader) ([]model.ScanFinding, error) {
+	decoder := xml.NewDecoder(r)
+	findings := make([]model.ScanFinding, 0)
+
+	var scanStart time.Time
+	sawRun := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) || (sawRun && truncated(err)) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("無效的 nmap XML: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "nmaprun":
+			sawRun = true
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "start" {
+					if sec, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+						scanStart = time.Unix(sec, 0).UTC()
+					}
+				}
+			}
+		case "host":
+			var host nmapHost
+			if err := decoder.DecodeElement(&host, &start); err != nil {
+				if truncated(err) {
+					return findings, nil
+				}
+				return nil, fmt.Errorf("無效的 nmap host 元素: %w", err)
+			}
+			hostFindings, err := host.toFindings(scanStart)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, hostFindings...)
+		}
+	}
+
+	if !sawRun {
+		return nil, errors.New("不是 nmap XML 輸出（缺少 nmaprun 元素）")
+	}
+	return findings, nil
+}
+
+// truncated 判斷 XML 錯誤是否為輸出在中途結束
+func truncated(err error) bool {
+	var syntaxErr *xml.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Msg == "unexpected EOF"
+}
+
+// toFindings 將主機轉換為主機清單與開放埠發現
+func (h *nmapHost) toFindings(scanStart time.Time) ([]model.ScanFinding, error) {
+	if h.Status.State != "up" {
+		return nil, nil
+	}
+
+	address, addresses, mac, vendor := h.addresses()
+	hostnames := h.hostnames()
+	discoveredAt := scanStart
+	if h.EndTime > 0 {
+		discoveredAt = time.Unix(h.EndTime, 0).UTC()
+	}
+
+	findings := make([]model.ScanFinding, 0, len(h.Ports)+1)
+	openPorts := make([]string, 0, len(h.Ports))
+	for _, port := range h.Ports {
+		if port.State.State != "open" {
+			continue
+		}
+		openPorts = append(openPorts, fmt.Sprintf("%d/%s", port.PortID, port.Protocol))
+
+		finding, err := port.toFinding(address, hostnames)
+		if err != nil {
+			return nil, err
+		}
+		finding.DiscoveredAt = discoveredAt
+		findings = append(findings, finding)
+	}
+
+	inventory := nmapHostEvidence{
+		Type:      "host",
+		Addresses: addresses,
+		MAC:       mac,
+		MACVendor: vendor,
+		Hostnames: hostnames,
+		OpenPorts: openPorts,
+		Scripts:   scriptOutputs(h.Scripts),
+	}
+	if len(h.OSMatches) > 0 {
+		inventory.OS = h.OSMatches[0].Name
+		inventory.OSAccuracy = h.OSMatches[0].Accuracy
+	}
+	evidence, err := json.Marshal(inventory)
+	if err != nil {
+		return nil, err
+	}
+
+	title := "主機存活: " + address
+	if len(hostnames) > 0 {
+		title += " (" + hostnames[0] + ")"
+	}
+	hostFinding := model.ScanFinding{
+		Severity:     "info",
+		Title:        truncate(title, 255),
+		Description:  fmt.Sprintf("主機 %s 存活，共 %d 個開放埠", address, len(openPorts)),
+		Host:         truncate(address, 255),
+		Evidence:     string(evidence),
+		DiscoveredAt: discoveredAt,
+	}
+
+	return append([]model.ScanFinding{hostFinding}, findings...), nil
+}
+
+// toFinding 將開放埠轉換為 ScanFinding
+func (p *nmapPort) toFinding(address string, hostnames []string) (model.ScanFinding, error) {
+	vulns, vulnerable := p.vulns()
+
+	evidence, err := json.Marshal(nmapPortEvidence{
+		Hostnames: hostnames,
+		State:     p.State.State,
+		Reason:    p.State.Reason,
+		Service:   p.Service.Name,
+		Product:   p.Service.Product,
+		Version:   p.Service.Version,
+		ExtraInfo: p.Service.ExtraInfo,
+		Tunnel:    p.Service.Tunnel,
+		CPEs:      p.Service.CPEs,
+		Scripts:   scriptOutputs(p.Scripts),
+		Vulns:     vulns,
+	})
+	if err != nil {
+		return model.ScanFinding{}, err
+	}
+
+	service := strings.TrimSpace(strings.Join([]string{p.Service.Name, p.Service.Product, p.Service.Version}, " "))
+	endpoint := fmt.Sprintf("%d/%s", p.PortID, p.Protocol)
+
+	finding := model.ScanFinding{
+		Severity:    "info",
+		Title:       truncate(strings.TrimSpace("開放埠 "+endpoint+" "+service), 255),
+		Description: fmt.Sprintf("主機 %s 的 %s 為開放狀態", address, endpoint),
+		Host:        truncate(address, 255),
+		Port:        p.PortID,
+		Protocol:    truncate(p.Protocol, 20),
+		Evidence:    string(evidence),
+	}
+
+	// 以 CVSS 最高的 CVE 作為代表
+	if len(vulns) > 0 {
+		top := vulns[0]
+		finding.CVEID = top.CVEID
+		finding.CVSSScore = top.CVSSScore
+		finding.Title = truncate(top.CVEID+" - "+endpoint+" "+service, 255)
+		finding.Description = fmt.Sprintf("主機 %s 的 %s 服務偵測到 %d 個已知弱點", address, endpoint, len(vulns))
+
+		refs := make([]string, 0, len(vulns))
+		for _, v := range vulns {
+			refs = append(refs, "https://nvd.nist.gov/vuln/detail/"+v.CVEID)
+		}
+		finding.References = textArray(refs)
+
+		switch {
+		case top.CVSSScore != nil:
+			finding.Severity = severityFromCVSS(*top.CVSSScore)
+		case vulnerable:
+			finding.Severity = "high"
+		default:
+			finding.Severity = "medium"
+		}
+	} else if vulnerable {
+		finding.Severity = "high"
+		finding.Title = truncate("弱點 - "+endpoint+" "+service, 255)
+	}
+
+	return finding, nil
+}
+
+// vulns 從 NSE 腳本收集 CVE（依 CVSS 由高到低排序），並回報是否有腳本判定為 VULNERABLE
+func (p *nmapPort) vulns() ([]nmapVuln, bool) {
+	byID := make(map[string]*float64)
+	vulnerable := false
+
+	record := func(id string, score *float64) {
+		id = strings.ToUpper(id)
+		if existing, ok := byID[id]; ok && existing != nil && (score == nil || *existing >= *score) {
+			return
+		}
+		byID[id] = score
+	}
+
+	for _, script := range p.Scripts {
+		// 結構化表格：vulners 為 {id, cvss, type}；vuln 類腳本以 CVE 為 key 並附帶 state
+		var walk func(tables []nmapTable)
+		walk = func(tables []nmapTable) {
+			for _, table := range tables {
+				fields := make(map[string]string, len(table.Elems))
+				for _, elem := range table.Elems {
+					fields[elem.Key] = strings.TrimSpace(elem.Value)
+				}
+
+				state := strings.ToUpper(fields["state"])
+				if strings.HasPrefix(state, "NOT") {
+					walk(table.Tables)
+					continue
+				}
+				if strings.Contains(state, "VULNERABLE") {
+					vulnerable = true
+				}
+
+				if id := cvePattern.FindString(strings.ToUpper(fields["id"])); id != "" {
+					var score *float64
+					if v, err := strconv.ParseFloat(fields["cvss"], 64); err == nil {
+						score = &v
+					}
+					record(id, score)
+				} else {
+					for _, id := range cvePattern.FindAllString(strings.ToUpper(table.Key), -1) {
+						record(id, nil)
+					}
+					for _, elem := range table.Elems {
+						for _, id := range cvePattern.FindAllString(strings.ToUpper(elem.Value), -1) {
+							record(id, nil)
+						}
+					}
+				}
+				walk(table.Tables)
+			}
+		}
+		walk([]nmapTable{{Tables: script.Tables, Elems: script.Elems}})
+
+		// 舊版純文字輸出
+		if len(script.Tables) == 0 && len(script.Elems) == 0 {
+			for _, m := range vulnersLinePattern.FindAllStringSubmatch(script.Output, -1) {
+				if v, err := strconv.ParseFloat(m[2], 64); err == nil {
+					record(m[1], &v)
+				}
+			}
+			for _, id := range cvePattern.FindAllString(script.Output, -1) {
+				if _, ok := byID[id]; !ok {
+					record(id, nil)
+				}
+			}
+			if strings.Contains(script.Output, "State: VULNERABLE") || strings.Contains(script.Output, "State: LIKELY VULNERABLE") {
+				vulnerable = true
+			}
+		}
+	}
+
+	vulns := make([]nmapVuln, 0, len(byID))
+	for id, score := range byID {
+		vulns = append(vulns, nmapVuln{CVEID: id, CVSSScore: score})
+	}
+	sort.Slice(vulns, func(i, j int) bool {
+		si, sj := vulns[i].CVSSScore, vulns[j].CVSSScore
+		switch {
+		case si != nil && sj != nil && *si != *sj:
+			return *si > *sj
+		case (si == nil) != (sj == nil):
+			return si != nil
+		default:
+			return vulns[i].CVEID > vulns[j].CVEID
+		}
+	})
+
+	return vulns, vulnerable
+}
+
+// addresses 取得主要 IP、所有 IP 與 MAC 位址
+func (h *nmapHost) addresses() (string, []string, string, string) {
+	primary := ""
+	ips := make([]string, 0, len(h.Addresses))
+	mac, vendor := "", ""
+	for _, addr := range h.Addresses {
+		switch addr.AddrType {
+		case "mac":
+			mac, vendor = addr.Addr, addr.Vendor
+		case "ipv4":
+			if primary == "" || !strings.Contains(primary, ".") {
+				primary = addr.Addr
+			}
+			ips = append(ips, addr.Addr)
+		default:
+			if primary == "" {
+				primary = addr.Addr
+			}
+			ips = append(ips, addr.Addr)
+		}
+	}
+	return primary, ips, mac, vendor
+}
+
+// hostnames 取得不重複的主機名稱（使用者指定的優先）
+func (h *nmapHost) hostnames() []string {
+	names := make([]string, 0, len(h.Hostnames))
+	seen := make(map[string]bool, len(h.Hostnames))
+	sorted := append([]nmapHostname(nil), h.Hostnames...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Type == "user" && sorted[j].Type != "user"
+	})
+	for _, hn := range sorted {
+		if hn.Name != "" && !seen[hn.Name] {
+			seen[hn.Name] = true
+			names = append(names, hn.Name)
+		}
+	}
+	return names
+}
+
+// scriptOutputs 取得 NSE 腳本輸出（script id → output）
+func scriptOutputs(scripts []nmapScript) map[string]string {
+	if len(scripts) == 0 {
+		return nil
+	}
+	outputs := make(map[string]string, len(scripts))
+	for _, s := range scripts {
+		outputs[s.ID] = strings.TrimSpace(s.Output)
+	}
+	return outputs
+}
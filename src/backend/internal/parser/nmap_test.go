@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readFixture 讀取 testdata 中的測試資料
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseNmap(t *testing.T) {
+	findings, err := ParseNmap(strings.NewReader(readFixture(t, "nmap.xml")))
+	if err != nil {
+		t.Fatalf("ParseNmap: %v", err)
+	}
+
+	tests := []struct {
+		title    string
+		severity string
+		host     string
+		port     int
+		cve      string
+		cvss     float64
+		refs     int
+	}{
+		// 使用者指定的主機名稱優先；關閉的埠與未存活的主機不產生發現
+		{"主機存活: 10.0.0.5 (www.example.com)", "info", "10.0.0.5", 0, "", 0, 0},
+		{"開放埠 22/tcp ssh OpenSSH 8.9p1", "info", "10.0.0.5", 22, "", 0, 0},
+		// vulners 表格：以 CVSS 最高的 CVE 為代表，非 CVE 的項目略過
+		{"CVE-2021-42013 - 80/tcp http Apache httpd 2.4.49", "critical", "10.0.0.5", 80, "CVE-2021-42013", 9.8, 2},
+		// vulners 舊版純文字輸出
+		{"CVE-2021-23017 - 443/tcp http nginx", "high", "10.0.0.5", 443, "CVE-2021-23017", 7.7, 2},
+		// vuln 類腳本判定 VULNERABLE 但沒有 CVE；NOT VULNERABLE 的 CVE 不採用
+		{"弱點 - 445/tcp microsoft-ds", "high", "10.0.0.5", 445, "", 0, 0},
+		{"主機存活: 10.0.0.7", "info", "10.0.0.7", 0, "", 0, 0},
+	}
+	if len(findings) != len(tests) {
+		t.Fatalf("findings = %d, want %d", len(findings), len(tests))
+	}
+	for i, tt := range tests {
+		f := findings[i]
+		cvss := 0.0
+		if f.CVSSScore != nil {
+			cvss = *f.CVSSScore
+		}
+		refs := 0
+		if f.References != "" {
+			refs = strings.Count(f.References, ",") + 1
+		}
+		if f.Title != tt.title || f.Severity != tt.severity || f.Host != tt.host || f.Port != tt.port ||
+			f.CVEID != tt.cve || cvss != tt.cvss || refs != tt.refs {
+			t.Errorf("finding %d = {%q %s %s %d %q %v %d}, want %+v",
+				i, f.Title, f.Severity, f.Host, f.Port, f.CVEID, cvss, refs, tt)
+		}
+	}
+
+	if want := time.Unix(1714557660, 0).UTC(); !findings[0].DiscoveredAt.Equal(want) {
+		t.Errorf("discovered_at = %v, want host endtime %v", findings[0].DiscoveredAt, want)
+	}
+
+	var inventory nmapHostEvidence
+	if err := json.Unmarshal([]byte(findings[0].Evidence), &inventory); err != nil {
+		t.Fatalf("host evidence: %v", err)
+	}
+	if inventory.Type != "host" || inventory.MAC != "00:11:22:33:44:55" || inventory.OS != "Linux 5.0 - 5.14" ||
+		strings.Join(inventory.OpenPorts, ",") != "22/tcp,80/tcp,443/tcp,445/tcp" ||
+		strings.Join(inventory.Hostnames, ",") != "www.example.com,web.example.com" {
+		t.Errorf("host evidence = %+v", inventory)
+	}
+
+	var port nmapPortEvidence
+	if err := json.Unmarshal([]byte(findings[2].Evidence), &port); err != nil {
+		t.Fatalf("port evidence: %v", err)
+	}
+	if len(port.Vulns) != 2 || port.Vulns[1].CVEID != "CVE-2021-41773" || port.Product != "Apache httpd" {
+		t.Errorf("port evidence = %+v", port)
+	}
+}
+
+func TestParseNmapTruncated(t *testing.T) {
+	full := readFixture(t, "nmap.xml")
+	secondHost := strings.Index(full, `<host starttime="1714557600" endtime="1714557661">`)
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		// 掃描被取消時輸出在主機元素中途結束，保留已完整輸出的主機
+		{"inside second host", full[:secondHost+60], 5},
+		{"inside first host", full[:strings.Index(full, `portid="80"`)], 0},
+		{"after nmaprun start", full[:strings.Index(full, "<host ")], 0},
+	}
+	for _, tt := range tests {
+		findings, err := ParseNmap(strings.NewReader(tt.input))
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if len(findings) != tt.want {
+			t.Errorf("%s: findings = %d, want %d", tt.name, len(findings), tt.want)
+		}
+	}
+}
+
+func TestParseNmapErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "不是 nmap XML 輸出"},
+		{"other xml", `<?xml version="1.0"?><report></report>`, "不是 nmap XML 輸出"},
+		{"plain text", "Nmap scan report for example.com\n", "不是 nmap XML 輸出"},
+		{"truncated before nmaprun", `<?xml version="1.0"?><nmap`, "無效的 nmap XML"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseNmap(strings.NewReader(tt.input)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
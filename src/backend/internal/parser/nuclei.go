@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// maxNucleiLineBytes nuclei 單行 JSON 上限（包含 request/response 時可能很大）
+const maxNucleiLineBytes = 16 * 1024 * 1024
+
+// nucleiResult nuclei -jsonl 輸出的單筆結果
+type nucleiResult struct {
+	TemplateID       string          `json:"template-id"`
+	TemplatePath     string          `json:"template-path"`
+	Info             nucleiInfo      `json:"info"`
+	Type             string          `json:"type"`
+	Host             string          `json:"host"`
+	Port             json.RawMessage `json:"port"`
+	Scheme           string          `json:"scheme"`
+	URL              string          `json:"url"`
+	MatchedAt        string          `json:"matched-at"`
+	MatcherName      string          `json:"matcher-name"`
+	ExtractorName    string          `json:"extractor-name"`
+	ExtractedResults []string        `json:"extracted-results"`
+	IP               string          `json:"ip"`
+	Timestamp        time.Time       `json:"timestamp"`
+	CurlCommand      string          `json:"curl-command"`
+}
+
+// nucleiInfo nuclei 模板資訊
+type nucleiInfo struct {
+	Name           string               `json:"name"`
+	Tags           json.RawMessage      `json:"tags"`
+	Description    string               `json:"description"`
+	Reference      json.RawMessage      `json:"reference"`
+	Severity       string               `json:"severity"`
+	Remediation    string               `json:"remediation"`
+	Classification nucleiClassification `json:"classification"`
+}
+
+// nucleiClassification nuclei 模板分類（CVE/CWE/CVSS）
+type nucleiClassification struct {
+	CVEID       json.RawMessage `json:"cve-id"`
+	CWEID       json.RawMessage `json:"cwe-id"`
+	CVSSMetrics string          `json:"cvss-metrics"`
+	CVSSScore   *float64        `json:"cvss-score"`
+}
+
+// nucleiEvidence 寫入 ScanFinding.Evidence 的證據內容
+type nucleiEvidence struct {
+	TemplateID       string   `json:"template_id"`
+	TemplatePath     string   `json:"template_path,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	MatchedAt        string   `json:"matched_at,omitempty"`
+	MatcherName      string   `json:"matcher_name,omitempty"`
+	ExtractorName    string   `json:"extractor_name,omitempty"`
+	ExtractedResults []string `json:"extracted_results,omitempty"`
+	IP               string   `json:"ip,omitempty"`
+	CVSSMetrics      string   `json:"cvss_metrics,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	CurlCommand      string   `json:"curl_command,omitempty"`
+}
+
+// ParseNuclei 解析 nuclei -jsonl 輸出（每行一筆 JSON 結果）
+func ParseNuclei(r io.Reader) ([]model.ScanFinding, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNucleiLineBytes)
+
+	findings := make([]model.ScanFinding, 0)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var result nucleiResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, fmt.Errorf("第 %d 行不是有效的 nuclei JSON: %w", lineNo, err)
+		}
+		if result.TemplateID == "" {
+			return nil, fmt.Errorf("第 %d 行缺少 template-id", lineNo)
+		}
+
+		finding, err := result.toFinding()
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", lineNo, err)
+		}
+		findings = append(findings, finding)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("讀取 nuclei 輸出失敗: %w", err)
+	}
+
+	return findings, nil
+}
+
+// toFinding 將 nuclei 結果轉換為 ScanFinding
+func (n *nucleiResult) toFinding() (model.ScanFinding, error) {
+	host, port, protocol := n.location()
+
+	evidence, err := json.Marshal(nucleiEvidence{
+		TemplateID:       n.TemplateID,
+		TemplatePath:     n.TemplatePath,
+		Type:             n.Type,
+		MatchedAt:        n.MatchedAt,
+		MatcherName:      n.MatcherName,
+		ExtractorName:    n.ExtractorName,
+		ExtractedResults: n.ExtractedResults,
+		IP:               n.IP,
+		CVSSMetrics:      n.Info.Classification.CVSSMetrics,
+		Tags:             stringList(n.Info.Tags),
+		CurlCommand:      n.CurlCommand,
+	})
+	if err != nil {
+		return model.ScanFinding{}, err
+	}
+
+	title := n.Info.Name
+	if title == "" {
+		title = n.TemplateID
+	}
+
+	finding := model.ScanFinding{
+		Severity:     normalizeSeverity(n.Info.Severity),
+		Title:        truncate(title, 255),
+		Description:  n.Info.Description,
+		Host:         truncate(host, 255),
+		Port:         port,
+		Protocol:     truncate(protocol, 20),
+		CVSSScore:    n.Info.Classification.CVSSScore,
+		CVEID:        truncate(strings.ToUpper(firstOf(stringList(n.Info.Classification.CVEID))), 50),
+		CWEID:        truncate(strings.ToUpper(firstOf(stringList(n.Info.Classification.CWEID))), 50),
+		Evidence:     string(evidence),
+		Remediation:  n.Info.Remediation,
+		DiscoveredAt: n.Timestamp,
+	}
+	if refs := stringList(n.Info.Reference); len(refs) > 0 {
+		finding.References = textArray(refs)
+	}
+
+	return finding, nil
+}
+
+// location 從 matched-at / host / port 推算主機、埠號與協定
+func (n *nucleiResult) location() (string, int, string) {
+	target := n.MatchedAt
+	if target == "" {
+		target = n.Host
+	}
+
+	host := n.Host
+	protocol := n.Scheme
+	port := 0
+
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		host = u.Hostname()
+		if protocol == "" {
+			protocol = u.Scheme
+		}
+		if p, err := strconv.Atoi(u.Port()); err == nil {
+			port = p
+		} else {
+			switch u.Scheme {
+			case "https":
+				port = 443
+			case "http":
+				port = 80
+			}
+		}
+	} else if h, p, err := net.SplitHostPort(target); err == nil {
+		host = h
+		port, _ = strconv.Atoi(p)
+	}
+
+	if p := jsonInt(n.Port); p > 0 {
+		port = p
+	}
+	if protocol == "" {
+		protocol = n.Type
+	}
+
+	return host, port, protocol
+}
+
+// stringList 解析可能為字串、字串陣列或 null 的 JSON 欄位
+func stringList(raw json.RawMessage) []string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return compact(list)
+	}
+
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return compact(strings.Split(single, ","))
+	}
+
+	return nil
+}
+
+// jsonInt 解析可能為數字或字串的 JSON 整數欄位
+func jsonInt(raw json.RawMessage) int {
+	if len(raw) == 0 {
+		return 0
+	}
+
+	var n int
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		n, _ = strconv.Atoi(s)
+	}
+	return n
+}
+
+// compact 去除空白與空字串
+func compact(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// firstOf 取得切片的第一個元素
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
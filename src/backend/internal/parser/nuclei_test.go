@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNuclei(t *testing.T) {
+	f, err := os.Open("testdata/nuclei.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	findings, err := ParseNuclei(f)
+	if err != nil {
+		t.Fatalf("ParseNuclei: %v", err)
+	}
+
+	tests := []struct {
+		title    string
+		severity string
+		host     string
+		port     int
+		protocol string
+		cve      string
+		cwe      string
+		refs     string
+	}{
+		{"Apache 2.4.49 - Path Traversal", "high", "example.com", 8443, "https", "CVE-2021-41773", "CWE-22",
+			`{"https://nvd.nist.gov/vuln/detail/CVE-2021-41773","https://httpd.apache.org/security/vulnerabilities_24.html"}`},
+		{"Wappalyzer Technology Detection", "info", "example.com", 80, "http", "", "",
+			`{"https://github.com/projectdiscovery/nuclei-templates"}`},
+		// 缺少 info.name 時以 template-id 作為標題；非 URL 的 host:port
+		{"redis-unauth", "critical", "10.0.0.5", 6379, "network", "", "", ""},
+		{"Weak Cipher Suites", "medium", "example.com", 443, "ssl", "", "", ""},
+		// 未知的嚴重程度視為 info；沒有 matched-at 時使用 host
+		{"Custom", "info", "example.com", 0, "dns", "", "", ""},
+	}
+	if len(findings) != len(tests) {
+		t.Fatalf("findings = %d, want %d (blank lines are skipped)", len(findings), len(tests))
+	}
+	for i, tt := range tests {
+		f := findings[i]
+		if f.Title != tt.title || f.Severity != tt.severity || f.Host != tt.host || f.Port != tt.port ||
+			f.Protocol != tt.protocol || f.CVEID != tt.cve || f.CWEID != tt.cwe || f.References != tt.refs {
+			t.Errorf("finding %d = {%q %s %s %d %s %q %q %q}, want %+v",
+				i, f.Title, f.Severity, f.Host, f.Port, f.Protocol, f.CVEID, f.CWEID, f.References, tt)
+		}
+	}
+
+	first := findings[0]
+	if first.CVSSScore == nil || *first.CVSSScore != 7.5 {
+		t.Errorf("cvss = %v, want 7.5", first.CVSSScore)
+	}
+	if want := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC); !first.DiscoveredAt.Equal(want) {
+		t.Errorf("discovered_at = %v, want %v", first.DiscoveredAt, want)
+	}
+
+	var evidence nucleiEvidence
+	if err := json.Unmarshal([]byte(findings[1].Evidence), &evidence); err != nil {
+		t.Fatalf("evidence: %v", err)
+	}
+	if evidence.TemplateID != "tech-detect" || evidence.MatcherName != "nginx" ||
+		strings.Join(evidence.Tags, ",") != "tech,discovery" {
+		t.Errorf("evidence = %+v", evidence)
+	}
+}
+
+func TestParseNucleiErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string // 錯誤訊息需包含的文字；空字串表示不應失敗
+	}{
+		{"empty", "", ""},
+		{"blank lines", "\n  \n", ""},
+		{"invalid json", `{"template-id":"a","info":{}}` + "\nnot json\n", "第 2 行不是有效的 nuclei JSON"},
+		{"missing template-id", `{"info":{"name":"x","severity":"high"}}`, "第 1 行缺少 template-id"},
+		{"truncated line", `{"template-id":"a","info":{"seve`, "第 1 行不是有效的 nuclei JSON"},
+	}
+
+	for _, tt := range tests {
+		findings, err := ParseNuclei(strings.NewReader(tt.input))
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.want == "" && len(findings) != 0:
+			t.Errorf("%s: findings = %d, want 0", tt.name, len(findings))
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// Result 掃描工具輸出的解析結果
+type Result struct {
+	Findings []model.ScanFinding // 弱點類發現（nuclei、nmap）
+	Assets   []model.Asset       // 資產類發現（amass）
+}
+
+// Func 將掃描工具輸出解析為 Result
+type Func func(r io.Reader) (*Result, error)
+
+// parsers 依輸出格式註冊的解析器
+var parsers = map[string]Func{
+	"nuclei": Findings(ParseNuclei),
+	"nmap":   Findings(ParseNmap),
+	"amass":  Assets(ParseAmass),
+}
+
+// Findings 將只產生掃描發現的解析函式包裝為 Func
+func Findings(parse func(r io.Reader) ([]model.ScanFinding, error)) Func {
+	return func(r io.Reader) (*Result, error) {
+		findings, err := parse(r)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{Findings: findings}, nil
+	}
+}
+
+// Assets 將只產生資產的解析函式包裝為 Func
+func Assets(parse func(r io.Reader) ([]model.Asset, error)) Func {
+	return func(r io.Reader) (*Result, error) {
+		assets, err := parse(r)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{Assets: assets}, nil
+	}
+}
+
+// ForFormat 根據輸出格式（通常與 scan_type 相同）取得解析器
+func ForFormat(format string) (Func, error) {
+	p, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("不支援的結果格式: %s（支援：%s）", format, strings.Join(Formats(), ", "))
+	}
+	return p, nil
+}
+
+// Formats 取得所有支援的輸出格式
+func Formats() []string {
+	formats := make([]string, 0, len(parsers))
+	for f := range parsers {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// normalizeSeverity 將工具的嚴重性對應到 ScanFinding 允許的值
+func normalizeSeverity(severity string) string {
+	switch s := strings.ToLower(strings.TrimSpace(severity)); s {
+	case "critical", "high", "medium", "low", "info":
+		return s
+	case "moderate":
+		return "medium"
+	default:
+		return "info"
+	}
+}
+
+// severityFromCVSS 根據 CVSS v3 分數推算嚴重性
+func severityFromCVSS(score float64) string {
+	switch {
+	case score >= 9.0:
+		return "critical"
+	case score >= 7.0:
+		return "high"
+	case score >= 4.0:
+		return "medium"
+	case score > 0:
+		return "low"
+	default:
+		return "info"
+	}
+}
+
+// textArray 將字串切片轉為 PostgreSQL text[] 字面值
+func textArray(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ReplaceAll(v, `\`, `\\`)
+		v = strings.ReplaceAll(v, `"`, `\"`)
+		quoted = append(quoted, `"`+v+`"`)
+	}
+	return "{" + strings.Join(quoted, ",") + "}"
+}
+
+// truncate 截斷字串至指定長度（以 rune 計算，避免切斷多位元組字元）
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository API 金鑰資料存取層
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository 建立新的 APIKeyRepository
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create 建立 API 金鑰
+func (r *APIKeyRepository) Create(key *model.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// FindByHash 根據金鑰雜湊查詢（包含所屬使用者）
+func (r *APIKeyRepository) FindByHash(hash string) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.Preload("User").Where("key_hash = ?", hash).First(&key).Error
+	return &key, err
+}
+
+// FindByUser 查詢使用者的所有 API 金鑰
+func (r *APIKeyRepository) FindByUser(userID uint) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// FindByIDForUser 查詢屬於指定使用者的 API 金鑰
+func (r *APIKeyRepository) FindByIDForUser(id, userID uint) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&key).Error
+	return &key, err
+}
+
+// Revoke 撤銷 API 金鑰
+func (r *APIKeyRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// TouchLastUsed 更新最後使用時間（不更新 updated_at）
+func (r *APIKeyRepository) TouchLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&model.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AssetRepository 資產資料存取層
+type AssetRepository struct {
+	db *gorm.DB
+}
+
+// NewAssetRepository 建立新的 AssetRepository
+func NewAssetRepository(db *gorm.DB) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+// WithTx 回傳使用指定交易的 AssetRepository
+func (r *AssetRepository) WithTx(tx *gorm.DB) *AssetRepository {
+	return &AssetRepository{db: tx}
+}
+
+// Upsert 批次寫入資產；主機名稱已存在時合併 IP 與資料來源並更新最後發現時間
+func (r *AssetRepository) Upsert(assets []model.Asset) error {
+	if len(assets) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "hostname"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"domain":           gorm.Expr("COALESCE(NULLIF(excluded.domain, ''), assets.domain)"),
+			"ip_addresses":     gorm.Expr("ARRAY(SELECT DISTINCT unnest(assets.ip_addresses || excluded.ip_addresses))"),
+			"asn":              gorm.Expr("COALESCE(NULLIF(excluded.asn, 0), assets.asn)"),
+			"asn_description":  gorm.Expr("COALESCE(NULLIF(excluded.asn_description, ''), assets.asn_description)"),
+			"sources":          gorm.Expr("ARRAY(SELECT DISTINCT unnest(assets.sources || excluded.sources))"),
+			"last_scan_job_id": gorm.Expr("excluded.last_scan_job_id"),
+			"last_seen":        gorm.Expr("GREATEST(assets.last_seen, excluded.last_seen)"),
+			"updated_at":       gorm.Expr("excluded.updated_at"),
+		}),
+	}).CreateInBatches(&assets, 500).Error
+}
+
+// FindByID 根據 ID 查詢資產
+func (r *AssetRepository) FindByID(id uint) (*model.Asset, error) {
+	var asset model.Asset
+	err := r.db.First(&asset, id).Error
+	return &asset, err
+}
+
+// FindAll 查詢資產（分頁）
+func (r *AssetRepository) FindAll(params *dto.AssetQueryParams) ([]model.Asset, int64, error) {
+	var assets []model.Asset
+	var total int64
+
+	query := r.db.Model(&model.Asset{})
+
+	// 應用過濾條件
+	if params.Q != "" {
+		// 跳脫 % 與 _，使搜尋字串以字面比對
+		query = query.Where(`hostname ILIKE ? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(params.Q))+"%")
+	}
+	if params.Domain != "" {
+		query = query.Where("domain = ?", strings.ToLower(params.Domain))
+	}
+	if params.IP != "" {
+		query = query.Where("? = ANY(ip_addresses)", params.IP)
+	}
+	if params.ASN > 0 {
+		query = query.Where("asn = ?", params.ASN)
+	}
+	if params.Source != "" {
+		query = query.Where("source = ?", params.Source)
+	}
+	if params.ScanJobID > 0 {
+		query = query.Where("scan_job_id = ? OR last_scan_job_id = ?", params.ScanJobID, params.ScanJobID)
+	}
+	if params.SeenSince != nil {
+		query = query.Where("last_seen >= ?", *params.SeenSince)
+	}
+
+	// 計算總數
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 應用分頁
+	if params.Page > 0 && params.PageSize > 0 {
+		offset := (params.Page - 1) * params.PageSize
+		query = query.Offset(offset).Limit(params.PageSize)
+	}
+
+	// 排序並查詢
+	err := query.Order("last_seen DESC, id DESC").Find(&assets).Error
+	return assets, total, err
+}
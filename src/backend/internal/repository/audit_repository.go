@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// AuditRepository 稽核紀錄資料存取層
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository 建立新的 AuditRepository
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create 建立稽核紀錄
+func (r *AuditRepository) Create(entry *model.AuditLog) error {
+	return r.db.Create(entry).Error
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// FindingCursor keyset 分頁位置：上一頁最後一筆的嚴重程度分數、CVSS（無分數為 -1）與 ID
+type FindingCursor struct {
+	Score int     `json:"s"`
+	CVSS  float64 `json:"c"`
+	ID    uint    `json:"i"`
+}
+
+// FindingRepository 掃描發現資料存取層
+type FindingRepository struct {
+	db *gorm.DB
+}
+
+// NewFindingRepository 建立新的 FindingRepository
+func NewFindingRepository(db *gorm.DB) *FindingRepository {
+	return &FindingRepository{db: db}
+}
+
+// FindByID 根據 ID 查詢掃描發現
+func (r *FindingRepository) FindByID(id uint) (*model.ScanFinding, error) {
+	var finding model.ScanFinding
+	err := r.db.First(&finding, id).Error
+	return &finding, err
+}
+
+// FindByIDs 根據多個 ID 查詢掃描發現（不存在的 ID 直接略過）
+func (r *FindingRepository) FindByIDs(ids []uint) ([]model.ScanFinding, error) {
+	var findings []model.ScanFinding
+	err := r.db.Where("id IN ?", ids).Order("id").Find(&findings).Error
+	return findings, err
+}
+
+// SaveTriage 在同一交易中寫入掃描發現的分流欄位
+func (r *FindingRepository) SaveTriage(findings []model.ScanFinding) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range findings {
+			err := tx.Model(&findings[i]).
+				Select("triage_status", "assignee", "justification", "risk_accepted_until", "triaged_by", "triaged_at", "updated_at").
+				Updates(&findings[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindLatestTriaged 查詢同一目標其他掃描任務中具有指定指紋且已分流的發現（依分流時間由新到舊）
+func (r *FindingRepository) FindLatestTriaged(target string, excludeScanJobID uint, fingerprints []string) ([]model.ScanFinding, error) {
+	var findings []model.ScanFinding
+	if len(fingerprints) == 0 {
+		return findings, nil
+	}
+	err := r.db.
+		Joins("JOIN scan_jobs ON scan_jobs.id = scan_findings.scan_job_id AND scan_jobs.deleted_at IS NULL").
+		Where("scan_jobs.target = ? AND scan_findings.scan_job_id <> ? AND scan_findings.triaged_at IS NOT NULL", target, excludeScanJobID).
+		Where("scan_findings.fingerprint IN ?", fingerprints).
+		Order("scan_findings.triaged_at DESC, scan_findings.id DESC").
+		Find(&findings).Error
+	return findings, err
+}
+
+// FindAll 跨掃描查詢發現（排除已刪除的掃描任務），依 sort/order 排序並從 cursor 之後取 limit 筆
+// cursor 為 nil 時從第一筆開始；sort 為 severity 時依嚴重程度分數、CVSS、ID 排序；為 cvss 時依 CVSS、嚴重程度分數、ID 排序
+func (r *FindingRepository) FindAll(params *dto.FindingQueryParams, severities []string, cursor *FindingCursor, limit int) ([]model.ScanFinding, error) {
+	var findings []model.ScanFinding
+
+	query := r.db.Model(&model.ScanFinding{}).
+		Joins("JOIN scan_jobs ON scan_jobs.id = scan_findings.scan_job_id AND scan_jobs.deleted_at IS NULL")
+
+	// 應用過濾條件
+	if len(severities) > 0 {
+		query = query.Where("scan_findings.severity IN ?", severities)
+	}
+	if params.CVE != "" {
+		query = query.Where("scan_findings.cve_id = ?", strings.ToUpper(params.CVE))
+	}
+	if params.CWE != "" {
+		query = query.Where("scan_findings.cwe_id = ?", strings.ToUpper(params.CWE))
+	}
+	if params.Host != "" {
+		query = query.Where("scan_findings.host = ?", params.Host)
+	}
+	if params.Port > 0 {
+		query = query.Where("scan_findings.port = ?", params.Port)
+	}
+	if params.CVSSMin != nil {
+		query = query.Where("scan_findings.cvss_score >= ?", *params.CVSSMin)
+	}
+	if params.CVSSMax != nil {
+		query = query.Where("scan_findings.cvss_score <= ?", *params.CVSSMax)
+	}
+	if params.ScanType != "" {
+		query = query.Where("scan_jobs.scan_type = ?", params.ScanType)
+	}
+	if params.Target != "" {
+		query = query.Where("scan_jobs.target = ?", params.Target)
+	}
+	if params.TriageStatus != "" {
+		query = query.Where("scan_findings.triage_status = ?", params.TriageStatus)
+	}
+	if params.DiscoveredFrom != nil {
+		query = query.Where("scan_findings.discovered_at >= ?", *params.DiscoveredFrom)
+	}
+	if params.DiscoveredTo != nil {
+		query = query.Where("scan_findings.discovered_at < ?", *params.DiscoveredTo)
+	}
+
+	// 排序鍵（與 migration 000013 的索引一致）
+	keys := []string{"scan_findings.severity_score", "COALESCE(scan_findings.cvss_score, -1)", "scan_findings.id"}
+	if params.Sort == "cvss" {
+		keys[0], keys[1] = keys[1], keys[0]
+	}
+	direction, compare := "DESC", "<"
+	if params.Order == "asc" {
+		direction, compare = "ASC", ">"
+	}
+
+	// 應用 keyset 分頁
+	if cursor != nil {
+		values := []interface{}{cursor.Score, cursor.CVSS, cursor.ID}
+		placeholders := []string{"?", "?::numeric", "?"}
+		if params.Sort == "cvss" {
+			values[0], values[1] = values[1], values[0]
+			placeholders[0], placeholders[1] = placeholders[1], placeholders[0]
+		}
+		query = query.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(keys, ", "), compare, strings.Join(placeholders, ", ")), values...)
+	}
+
+	order := make([]string, 0, len(keys))
+	for _, key := range keys {
+		order = append(order, key+" "+direction)
+	}
+
+	err := query.Order(strings.Join(order, ", ")).Limit(limit).Find(&findings).Error
+	return findings, err
+}
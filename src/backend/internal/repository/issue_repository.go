@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IssueRepository 問題資料存取層
+type IssueRepository struct {
+	db *gorm.DB
+}
+
+// NewIssueRepository 建立新的 IssueRepository
+func NewIssueRepository(db *gorm.DB) *IssueRepository {
+	return &IssueRepository{db: db}
+}
+
+// WithTx 回傳使用指定交易的 IssueRepository
+func (r *IssueRepository) WithTx(tx *gorm.DB) *IssueRepository {
+	return &IssueRepository{db: tx}
+}
+
+// Upsert 批次寫入問題並回填 ID；同一目標的指紋已存在時更新最後發現時間、重新開啟，
+// 並在來自新的掃描任務時遞增出現次數（同一掃描任務重複上傳不重複計算）
+func (r *IssueRepository) Upsert(issues []model.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "target"}, {Name: "fingerprint"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"severity":         gorm.Expr("excluded.severity"),
+			"title":            gorm.Expr("excluded.title"),
+			"cve_id":           gorm.Expr("excluded.cve_id"),
+			"status":           model.IssueStatusOpen,
+			"resolved_at":      nil,
+			"last_seen":        gorm.Expr("GREATEST(issues.last_seen, excluded.last_seen)"),
+			"occurrence_count": gorm.Expr("issues.occurrence_count + CASE WHEN issues.last_scan_job_id = excluded.last_scan_job_id THEN 0 ELSE 1 END"),
+			"last_scan_job_id": gorm.Expr("excluded.last_scan_job_id"),
+			"updated_at":       gorm.Expr("excluded.updated_at"),
+		}),
+	}).CreateInBatches(&issues, 500).Error
+}
+
+// ResolveMissing 將同一目標與工具中未出現在指定掃描任務的開放問題標記為已解決，回傳更新筆數
+func (r *IssueRepository) ResolveMissing(target, tool string, scanJobID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&model.Issue{}).
+		Where("target = ? AND tool = ? AND status = ? AND last_scan_job_id <> ?", target, tool, model.IssueStatusOpen, scanJobID).
+		Updates(map[string]interface{}{
+			"status":      model.IssueStatusResolved,
+			"resolved_at": at,
+			"updated_at":  at,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// FindByID 根據 ID 查詢問題
+func (r *IssueRepository) FindByID(id uint) (*model.Issue, error) {
+	var issue model.Issue
+	err := r.db.First(&issue, id).Error
+	return &issue, err
+}
+
+// FindAll 查詢問題（分頁、過濾）
+func (r *IssueRepository) FindAll(params *dto.IssueQueryParams) ([]model.Issue, int64, error) {
+	var issues []model.Issue
+	var total int64
+
+	query := r.db.Model(&model.Issue{})
+
+	// 應用過濾條件
+	if params.Target != "" {
+		query = query.Where("target = ?", params.Target)
+	}
+	if params.Tool != "" {
+		query = query.Where("tool = ?", params.Tool)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.Severity != "" {
+		query = query.Where("severity = ?", params.Severity)
+	}
+	if params.Host != "" {
+		query = query.Where("host = ?", params.Host)
+	}
+
+	// 計算總數
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 應用分頁
+	if params.Page > 0 && params.PageSize > 0 {
+		offset := (params.Page - 1) * params.PageSize
+		query = query.Offset(offset).Limit(params.PageSize)
+	}
+
+	// 排序並查詢
+	err := query.Order("last_seen DESC, id DESC").Find(&issues).Error
+	return issues, total, err
+}
+
+// IssueResolutionTrend 單一時間區間內解決的問題數量與平均修復時間
+type IssueResolutionTrend struct {
+	Bucket    time.Time
+	Count     int64
+	MTTRHours float64
+}
+
+// ResolvedTrend 依解決時間統計各時間區間解決的問題數量與平均修復時間（resolved_at - first_seen，小時）
+func (r *IssueRepository) ResolvedTrend(filter *TrendFilter) ([]IssueResolutionTrend, error) {
+	var results []IssueResolutionTrend
+	err := r.trendScope(filter).
+		Where("status = ? AND resolved_at >= ? AND resolved_at < ?", model.IssueStatusResolved, filter.From, filter.To).
+		Select(truncExpr(filter.Interval, "resolved_at") + " AS bucket, COUNT(*) AS count, " +
+			"AVG(EXTRACT(EPOCH FROM resolved_at - first_seen)) / 3600 AS mttr_hours").
+		Group("bucket").
+		Scan(&results).Error
+	return results, err
+}
+
+// OpenTrend 統計各時間區間結束時仍開放的問題數量（依嚴重程度）
+// 以 first_seen 與 resolved_at 推算，重新開啟的問題以最近一次狀態為準
+func (r *IssueRepository) OpenTrend(filter *TrendFilter, buckets []TrendBucket, severities []string) ([]TrendCount, error) {
+	var results []TrendCount
+	if len(buckets) == 0 {
+		return results, nil
+	}
+
+	starts := make([]time.Time, 0, len(buckets))
+	ends := make([]time.Time, 0, len(buckets))
+	for _, b := range buckets {
+		starts = append(starts, b.Start)
+		ends = append(ends, b.End)
+	}
+
+	err := r.trendScope(filter).
+		Joins("JOIN unnest(?::timestamptz[], ?::timestamptz[]) AS b(bucket_start, bucket_end) "+
+			"ON issues.first_seen < b.bucket_end AND (issues.resolved_at IS NULL OR issues.resolved_at >= b.bucket_end)",
+			timestampArray(starts), timestampArray(ends)).
+		Where("issues.severity IN ?", severities).
+		Select("b.bucket_start AS bucket, issues.severity AS name, COUNT(*) AS count").
+		Group("1, 2").
+		Scan(&results).Error
+	return results, err
+}
+
+// trendScope 套用趨勢查詢的目標與工具（掃描類型）過濾
+func (r *IssueRepository) trendScope(filter *TrendFilter) *gorm.DB {
+	query := r.db.Model(&model.Issue{})
+	if filter.Target != "" {
+		query = query.Where("issues.target = ?", filter.Target)
+	}
+	if filter.ScanType != "" {
+		query = query.Where("issues.tool = ?", filter.ScanType)
+	}
+	return query
+}
@@ -1,11 +1,22 @@
 package repository
 
 import (
+	"encoding/json"
+	"strings"
+	"time"
+
 	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
 	"github.com/dennislwm/unified-security-platform/backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ScanCursor keyset 分頁位置：上一頁最後一筆的建立時間與 ID
+type ScanCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"i"`
+}
+
 // ScanRepository 掃描資料存取層
 type ScanRepository struct {
 	db *gorm.DB
@@ -35,29 +46,115 @@ func (r *ScanRepository) FindByIDWithFindings(id uint) (*model.ScanJob, error) {
 	return &scan, err
 }
 
-// FindAll 查詢所有掃描任務（分頁）
-func (r *ScanRepository) FindAll(params *dto.ScanQueryParams) ([]model.ScanJob, int64, error) {
-	var scans []model.ScanJob
+// FindFindings 分頁查詢掃描任務的發現（依嚴重程度分數、CVSS 由高到低排序）
+func (r *ScanRepository) FindFindings(scanJobID uint, severities []string, offset, limit int) ([]model.ScanFinding, int64, error) {
+	var findings []model.ScanFinding
 	var total int64
 
-	query := r.db.Model(&model.ScanJob{})
+	query := r.db.Model(&model.ScanFinding{}).Where("scan_job_id = ?", scanJobID)
+	if len(severities) > 0 {
+		query = query.Where("severity IN ?", severities)
+	}
 
-	// 應用過濾條件
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
+	// 計算總數
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
-	if params.ScanType != "" {
-		query = query.Where("scan_type = ?", params.ScanType)
+
+	err := query.Order("severity_score DESC, COALESCE(cvss_score, -1) DESC, id").
+		Offset(offset).Limit(limit).Find(&findings).Error
+	return findings, total, err
+}
+
+// CountFindingsBySeverity 根據嚴重程度統計掃描任務的發現數量
+func (r *ScanRepository) CountFindingsBySeverity(scanJobID uint) (map[string]int64, error) {
+	type Result struct {
+		Severity string
+		Count    int64
 	}
-	if params.Target != "" {
-		query = query.Where("target LIKE ?", "%"+params.Target+"%")
+
+	var results []Result
+	err := r.db.Model(&model.ScanFinding{}).
+		Select("severity, COUNT(*) as count").
+		Where("scan_job_id = ?", scanJobID).
+		Group("severity").
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, r := range results {
+		counts[r.Severity] = r.Count
+	}
+
+	return counts, nil
+}
+
+// CountAllFindingsBySeverity 根據嚴重程度統計所有掃描任務的發現數量
+// from、to 以掃描任務的建立時間過濾（不含已刪除的掃描任務）
+func (r *ScanRepository) CountAllFindingsBySeverity(from, to *time.Time) (map[string]int64, error) {
+	type Result struct {
+		Severity string
+		Count    int64
+	}
+
+	query := r.db.Model(&model.ScanFinding{}).
+		Joins("JOIN scan_jobs ON scan_jobs.id = scan_findings.scan_job_id AND scan_jobs.deleted_at IS NULL")
+	if from != nil {
+		query = query.Where("scan_jobs.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("scan_jobs.created_at < ?", *to)
 	}
 
+	var results []Result
+	err := query.Select("scan_findings.severity, COUNT(*) as count").
+		Group("scan_findings.severity").
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, r := range results {
+		counts[r.Severity] = r.Count
+	}
+
+	return counts, nil
+}
+
+// FindPreviousCompleted 查詢同一目標與掃描類型中，比指定掃描任務更早建立的最近一次已完成掃描（包含發現）
+func (r *ScanRepository) FindPreviousCompleted(scan *model.ScanJob) (*model.ScanJob, error) {
+	var previous model.ScanJob
+	err := r.db.Preload("Findings").
+		Where("target = ? AND scan_type = ? AND status = ? AND id <> ?", scan.Target, scan.ScanType, model.ScanStatusCompleted, scan.ID).
+		Where("created_at < ? OR (created_at = ? AND id < ?)", scan.CreatedAt, scan.CreatedAt, scan.ID).
+		Order("created_at DESC, id DESC").
+		First(&previous).Error
+	return &previous, err
+}
+
+// FindAll 查詢所有掃描任務（分頁）；params.Estimate 時以查詢計畫估計總數
+func (r *ScanRepository) FindAll(params *dto.ScanQueryParams) ([]model.ScanJob, int64, error) {
+	var scans []model.ScanJob
+	var total int64
+
 	// 計算總數
-	if err := query.Count(&total).Error; err != nil {
+	var err error
+	if params.Estimate {
+		total, err = r.EstimateCount(params)
+	} else {
+		err = r.filtered(params).Count(&total).Error
+	}
+	if err != nil {
 		return nil, 0, err
 	}
 
+	query := r.filtered(params)
+
 	// 應用分頁
 	if params.Page > 0 && params.PageSize > 0 {
 		offset := (params.Page - 1) * params.PageSize
@@ -65,18 +162,216 @@ func (r *ScanRepository) FindAll(params *dto.ScanQueryParams) ([]model.ScanJob,
 	}
 
 	// 排序並查詢
-	err := query.Order("created_at DESC").Find(&scans).Error
+	err = query.Order("created_at DESC, id DESC").Find(&scans).Error
 	return scans, total, err
 }
 
+// FindAfter 以 keyset 分頁查詢掃描任務（建立時間新到舊），cursor 為 nil 時從第一筆開始
+func (r *ScanRepository) FindAfter(params *dto.ScanQueryParams, cursor *ScanCursor, limit int) ([]model.ScanJob, error) {
+	var scans []model.ScanJob
+
+	query := r.filtered(params)
+	if cursor != nil {
+		query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	// 排序鍵與 migration 000014 的索引一致
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&scans).Error
+	return scans, err
+}
+
+// EstimateCount 以 EXPLAIN 的估計筆數取得符合條件的掃描任務數量（不掃描資料表，結果為近似值）
+func (r *ScanRepository) EstimateCount(params *dto.ScanQueryParams) (int64, error) {
+	stmt := r.filtered(params).Session(&gorm.Session{DryRun: true}).Find(&[]model.ScanJob{}).Statement
+
+	var raw []byte
+	if err := r.db.Raw("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Row().Scan(&raw); err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, nil
+	}
+	return int64(plans[0].Plan.Rows), nil
+}
+
+// filtered 套用掃描任務的過濾條件
+// 目標比對使用 migration 000014 的索引：contains 走 trigram 索引，prefix 走 pattern_ops 索引
+func (r *ScanRepository) filtered(params *dto.ScanQueryParams) *gorm.DB {
+	query := r.db.Model(&model.ScanJob{})
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.ScanType != "" {
+		query = query.Where("scan_type = ?", params.ScanType)
+	}
+	if params.Target != "" {
+		pattern := escapeLike(params.Target) + "%"
+		if params.TargetMatch != "prefix" {
+			pattern = "%" + pattern
+		}
+		query = query.Where("target LIKE ?", pattern)
+	}
+	return query
+}
+
 // Update 更新掃描任務
 func (r *ScanRepository) Update(scan *model.ScanJob) error {
 	return r.db.Save(scan).Error
 }
 
+// ClaimPending 領取待執行的掃描任務並標記為執行中
+// 使用 FOR UPDATE SKIP LOCKED，多個 worker 同時輪詢時不會重複領取
+func (r *ScanRepository) ClaimPending(limit int) ([]model.ScanJob, error) {
+	var scans []model.ScanJob
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", model.ScanStatusPending).
+			Order("created_at ASC").
+			Limit(limit).
+			Find(&scans).Error
+		if err != nil || len(scans) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(scans))
+		for _, scan := range scans {
+			ids = append(ids, scan.ID)
+		}
+
+		now := time.Now().UTC()
+		err = tx.Model(&model.ScanJob{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": model.ScanStatusRunning, "started_at": now}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range scans {
+			scans[i].Status = model.ScanStatusRunning
+			scans[i].StartedAt = &now
+		}
+		return nil
+	})
+
+	return scans, err
+}
+
+// UpdateStatus 僅在目前狀態為 fromStatus 時更新掃描任務的狀態欄位
+// 狀態已被其他請求變更時回傳 false（樂觀並行控制）
+func (r *ScanRepository) UpdateStatus(scan *model.ScanJob, fromStatus string) (bool, error) {
+	result := r.db.Model(&model.ScanJob{}).
+		Where("id = ? AND status = ?", scan.ID, fromStatus).
+		Updates(statusFields(scan))
+	return result.RowsAffected > 0, result.Error
+}
+
+// SaveResults 在同一交易中執行 prepare（連結問題、寫入資產等）、寫入掃描發現，並在狀態仍為 fromStatus 時更新掃描任務
+// 狀態已被變更（例如已取消）時仍會寫入發現，但回傳 false
+func (r *ScanRepository) SaveResults(scan *model.ScanJob, fromStatus string, findings []model.ScanFinding, prepare func(tx *gorm.DB) error) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := prepare(tx); err != nil {
+			return err
+		}
+		if len(findings) > 0 {
+			if err := tx.CreateInBatches(findings, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&model.ScanJob{}).
+			Where("id = ? AND status = ?", scan.ID, fromStatus).
+			Updates(statusFields(scan))
+		updated = result.RowsAffected > 0
+		return result.Error
+	})
+	return updated, err
+}
+
+// SaveFindings 在同一交易中執行 prepare（連結問題、寫入資產等）並寫入掃描發現
+// 交易期間鎖定掃描任務；任務狀態已不是 status 時不寫入並回傳 false
+func (r *ScanRepository) SaveFindings(scanID uint, status string, findings []model.ScanFinding, prepare func(tx *gorm.DB) error) (bool, error) {
+	saved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked []model.ScanJob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ? AND status = ?", scanID, status).
+			Find(&locked).Error
+		if err != nil || len(locked) == 0 {
+			return err
+		}
+
+		if err := prepare(tx); err != nil {
+			return err
+		}
+		if len(findings) > 0 {
+			if err := tx.CreateInBatches(findings, 500).Error; err != nil {
+				return err
+			}
+		}
+		saved = true
+		return nil
+	})
+	return saved, err
+}
+
+// FindStaleRunning 查詢 started_at 早於 before 仍為 running 的掃描任務
+func (r *ScanRepository) FindStaleRunning(before time.Time, limit int) ([]model.ScanJob, error) {
+	var scans []model.ScanJob
+	err := r.db.Where("status = ? AND started_at < ?", model.ScanStatusRunning, before).
+		Order("started_at ASC").
+		Limit(limit).
+		Find(&scans).Error
+	return scans, err
+}
+
+// FindIDsByStatus 從指定的掃描任務中篩選出目前為 status 的 ID
+func (r *ScanRepository) FindIDsByStatus(ids []uint, status string) ([]uint, error) {
+	var result []uint
+	if len(ids) == 0 {
+		return result, nil
+	}
+	err := r.db.Model(&model.ScanJob{}).
+		Where("id IN ? AND status = ?", ids, status).
+		Pluck("id", &result).Error
+	return result, err
+}
+
+// statusFields 掃描任務狀態相關欄位
+func statusFields(scan *model.ScanJob) map[string]interface{} {
+	return map[string]interface{}{
+		"status":        scan.Status,
+		"started_at":    scan.StartedAt,
+		"completed_at":  scan.CompletedAt,
+		"error_message": scan.ErrorMessage,
+	}
+}
+
+// likeEscaper 跳脫 LIKE 萬用字元（PostgreSQL 預設跳脫字元為反斜線）
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike 跳脫 LIKE 的萬用字元，使輸入以字面比對
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
 // Delete 軟刪除掃描任務
-func (r *ScanRepository) Delete(id uint) error {
-	return r.db.Delete(&model.ScanJob{}, id).Error
+// 狀態已被其他請求或 worker 變更時不刪除並回傳 false
+func (r *ScanRepository) Delete(id uint, fromStatus string) (bool, error) {
+	result := r.db.Where("status = ?", fromStatus).Delete(&model.ScanJob{}, id)
+	return result.RowsAffected > 0, result.Error
 }
 
 // CountByStatus 根據狀態統計掃描任務數量
@@ -104,6 +399,98 @@ func (r *ScanRepository) CountByStatus() (map[string]int64, error) {
 	return counts, nil
 }
 
+// CountBy 依指定欄位統計建立時間在 [from, to) 內的掃描任務數量
+func (r *ScanRepository) CountBy(column string, from, to *time.Time) (map[string]int64, error) {
+	type Result struct {
+		Name  string
+		Count int64
+	}
+
+	var results []Result
+	err := createdBetween(r.db.Model(&model.ScanJob{}), from, to).
+		Select(column + " as name, COUNT(*) as count").
+		Group(column).
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, r := range results {
+		counts[r.Name] = r.Count
+	}
+
+	return counts, nil
+}
+
+// TargetStats 單一目標的掃描與發現統計
+type TargetStats struct {
+	Target     string
+	Scans      int64
+	Findings   int64
+	HighRisk   int64
+	LastScanAt *time.Time
+}
+
+// TargetBreakdown 依目標統計建立時間在 [from, to) 內的掃描任務與其發現
+// 依高風險（critical、high）發現數、發現總數排序，最多 limit 筆
+func (r *ScanRepository) TargetBreakdown(from, to *time.Time, limit int) ([]TargetStats, error) {
+	query := r.db.Model(&model.ScanJob{}).
+		Joins("LEFT JOIN scan_findings ON scan_findings.scan_job_id = scan_jobs.id")
+	if from != nil {
+		query = query.Where("scan_jobs.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("scan_jobs.created_at < ?", *to)
+	}
+
+	var results []TargetStats
+	err := query.Select("scan_jobs.target, COUNT(DISTINCT scan_jobs.id) AS scans, COUNT(scan_findings.id) AS findings, " +
+		"COUNT(scan_findings.id) FILTER (WHERE scan_findings.severity IN ('critical', 'high')) AS high_risk, " +
+		"MAX(scan_jobs.created_at) AS last_scan_at").
+		Group("scan_jobs.target").
+		Order("high_risk DESC, findings DESC, scan_jobs.target").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// ScanTrend 依時間區間統計掃描任務：started 依 started_at，completed 與 failed 依 completed_at
+func (r *ScanRepository) ScanTrend(filter *TrendFilter) ([]TrendCount, error) {
+	started, err := trendCounts(r.trendScope(filter), filter, "scan_jobs.started_at", "'started'")
+	if err != nil {
+		return nil, err
+	}
+
+	finished, err := trendCounts(
+		r.trendScope(filter).Where("scan_jobs.status IN ?", []string{model.ScanStatusCompleted, model.ScanStatusFailed}),
+		filter, "scan_jobs.completed_at", "scan_jobs.status")
+	if err != nil {
+		return nil, err
+	}
+	return append(started, finished...), nil
+}
+
+// FindingTrend 依發現時間（discovered_at）與嚴重程度統計各時間區間的發現數量
+func (r *ScanRepository) FindingTrend(filter *TrendFilter) ([]TrendCount, error) {
+	query := r.trendScope(filter).
+		Joins("JOIN scan_findings ON scan_findings.scan_job_id = scan_jobs.id")
+	return trendCounts(query, filter, "scan_findings.discovered_at", "scan_findings.severity")
+}
+
+// trendScope 套用趨勢查詢的目標與掃描類型過濾（不含已刪除的掃描任務）
+func (r *ScanRepository) trendScope(filter *TrendFilter) *gorm.DB {
+	query := r.db.Model(&model.ScanJob{})
+	if filter.Target != "" {
+		query = query.Where("scan_jobs.target = ?", filter.Target)
+	}
+	if filter.ScanType != "" {
+		query = query.Where("scan_jobs.scan_type = ?", filter.ScanType)
+	}
+	return query
+}
+
 // CountByScanType 根據掃描類型統計數量
 func (r *ScanRepository) CountByScanType() (map[string]int64, error) {
 	type Result struct {
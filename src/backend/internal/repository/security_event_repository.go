@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// ResolutionStat 單一嚴重程度已解決事件的數量與平均解決秒數
+type ResolutionStat struct {
+	Severity    string
+	Count       int64
+	MeanSeconds float64
+}
+
+// SecurityEventRepository 安全事件資料存取層
+type SecurityEventRepository struct {
+	db *gorm.DB
+}
+
+// NewSecurityEventRepository 建立新的 SecurityEventRepository
+func NewSecurityEventRepository(db *gorm.DB) *SecurityEventRepository {
+	return &SecurityEventRepository{db: db}
+}
+
+// Create 建立安全事件
+func (r *SecurityEventRepository) Create(event *model.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+// FindByID 根據 ID 查詢安全事件
+func (r *SecurityEventRepository) FindByID(id uint) (*model.SecurityEvent, error) {
+	var event model.SecurityEvent
+	err := r.db.First(&event, id).Error
+	return &event, err
+}
+
+// FindAll 查詢安全事件（分頁、過濾）
+func (r *SecurityEventRepository) FindAll(params *dto.SecurityEventQueryParams) ([]model.SecurityEvent, int64, error) {
+	var events []model.SecurityEvent
+	var total int64
+
+	query := r.db.Model(&model.SecurityEvent{})
+
+	// 應用過濾條件
+	if params.EventType != "" {
+		query = query.Where("event_type = ?", params.EventType)
+	}
+	if params.Severity != "" {
+		query = query.Where("severity = ?", params.Severity)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.AssignedTo != "" {
+		query = query.Where("assigned_to = ?", params.AssignedTo)
+	}
+	if params.Unassigned {
+		query = query.Where("assigned_to IS NULL OR assigned_to = ''")
+	}
+	query = createdBetween(query, params.From, params.To)
+
+	// 計算總數
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 應用分頁
+	if params.Page > 0 && params.PageSize > 0 {
+		offset := (params.Page - 1) * params.PageSize
+		query = query.Offset(offset).Limit(params.PageSize)
+	}
+
+	// 排序並查詢
+	err := query.Order("created_at DESC, id DESC").Find(&events).Error
+	return events, total, err
+}
+
+// UpdateAssignee 更新安全事件的指派對象
+func (r *SecurityEventRepository) UpdateAssignee(event *model.SecurityEvent) error {
+	return r.db.Model(event).Select("assigned_to", "updated_at").Updates(event).Error
+}
+
+// UpdateStatus 僅在目前狀態為 fromStatus 時更新安全事件的狀態與解決時間
+// 狀態已被其他請求變更時回傳 false（樂觀並行控制）
+func (r *SecurityEventRepository) UpdateStatus(event *model.SecurityEvent, fromStatus string) (bool, error) {
+	result := r.db.Model(&model.SecurityEvent{}).
+		Where("id = ? AND status = ?", event.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":      event.Status,
+			"resolved_at": event.ResolvedAt,
+			"updated_at":  time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountBy 依欄位統計安全事件數量（column 必須為固定欄位名稱）
+func (r *SecurityEventRepository) CountBy(column string, from, to *time.Time) (map[string]int64, error) {
+	type Result struct {
+		Name  string
+		Count int64
+	}
+
+	var results []Result
+	err := createdBetween(r.db.Model(&model.SecurityEvent{}), from, to).
+		Select(column + " as name, COUNT(*) as count").
+		Group(column).
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, r := range results {
+		counts[r.Name] = r.Count
+	}
+
+	return counts, nil
+}
+
+// CountResolvedByType 統計建立時間在 [from, to) 內、指定類型且已解決的事件數量
+func (r *SecurityEventRepository) CountResolvedByType(eventTypes []string, from, to *time.Time) (int64, error) {
+	var count int64
+	err := createdBetween(r.db.Model(&model.SecurityEvent{}), from, to).
+		Where("event_type IN ? AND status = ?", eventTypes, model.EventStatusResolved).
+		Count(&count).Error
+	return count, err
+}
+
+// ResolutionStats 依嚴重程度統計已解決事件的平均解決時間
+func (r *SecurityEventRepository) ResolutionStats(from, to *time.Time) ([]ResolutionStat, error) {
+	var results []ResolutionStat
+	err := createdBetween(r.db.Model(&model.SecurityEvent{}), from, to).
+		Select("severity, COUNT(*) as count, AVG(EXTRACT(EPOCH FROM resolved_at - created_at)) as mean_seconds").
+		Where("status = ? AND resolved_at IS NOT NULL", model.EventStatusResolved).
+		Group("severity").
+		Find(&results).Error
+	return results, err
+}
+
+// EventTrend 依時間區間統計安全事件：opened 依 created_at，resolved 依 resolved_at（僅已解決）
+func (r *SecurityEventRepository) EventTrend(filter *TrendFilter) ([]TrendCount, error) {
+	opened, err := trendCounts(r.db.Model(&model.SecurityEvent{}), filter, "created_at", "'opened'")
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := trendCounts(r.db.Model(&model.SecurityEvent{}).Where("status = ?", model.EventStatusResolved),
+		filter, "resolved_at", "'resolved'")
+	if err != nil {
+		return nil, err
+	}
+	return append(opened, resolved...), nil
+}
+
+// createdBetween 依建立時間範圍過濾（nil 表示不限制）
+func createdBetween(query *gorm.DB, from, to *time.Time) *gorm.DB {
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+	return query
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrendFilter 趨勢查詢條件：時間範圍 [From, To)、區間粒度（day、week、month）與目標、掃描類型過濾
+type TrendFilter struct {
+	Interval string
+	From     time.Time
+	To       time.Time
+	Target   string
+	ScanType string
+}
+
+// TrendBucket 趨勢的單一時間區間 [Start, End)
+type TrendBucket struct {
+	Start time.Time
+	End   time.Time
+}
+
+// TrendCount 單一時間區間內某個分組（狀態、嚴重程度等）的數量
+type TrendCount struct {
+	Bucket time.Time
+	Name   string
+	Count  int64
+}
+
+// truncExpr 以 UTC 將時間欄位截斷到區間起點的 SQL 運算式（粒度不在白名單時視為 day）
+func truncExpr(interval, column string) string {
+	switch interval {
+	case "week", "month":
+	default:
+		interval = "day"
+	}
+	return fmt.Sprintf("date_trunc('%s', %s, 'UTC')", interval, column)
+}
+
+// trendCounts 依時間欄位與分組運算式統計 [From, To) 內的數量
+func trendCounts(query *gorm.DB, filter *TrendFilter, column, name string) ([]TrendCount, error) {
+	var results []TrendCount
+	err := query.Where(column+" >= ? AND "+column+" < ?", filter.From, filter.To).
+		Select(truncExpr(filter.Interval, column) + " AS bucket, " + name + " AS name, COUNT(*) AS count").
+		Group("1, 2").
+		Scan(&results).Error
+	return results, err
+}
+
+// timestampArray 將時間轉為 PostgreSQL timestamptz[] 陣列字面值（GORM 會把 slice 參數展開為多個值）
+func timestampArray(times []time.Time) string {
+	values := make([]string, 0, len(times))
+	for _, t := range times {
+		values = append(values, t.UTC().Format(time.RFC3339Nano))
+	}
+	return "{" + strings.Join(values, ",") + "}"
+}
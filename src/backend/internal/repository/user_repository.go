@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// UserRepository 使用者資料存取層
+type UserRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository 建立新的 UserRepository
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create 建立使用者
+func (r *UserRepository) Create(user *model.User) error {
+	return r.db.Create(user).Error
+}
+
+// FindByID 根據 ID 查詢使用者
+func (r *UserRepository) FindByID(id uint) (*model.User, error) {
+	var user model.User
+	err := r.db.First(&user, id).Error
+	return &user, err
+}
+
+// FindByLogin 根據使用者名稱或 Email 查詢使用者
+func (r *UserRepository) FindByLogin(login string) (*model.User, error) {
+	var user model.User
+	err := r.db.Where("username = ? OR LOWER(email) = LOWER(?)", login, login).First(&user).Error
+	return &user, err
+}
+
+// UpdateLastLogin 更新最後登入時間
+func (r *UserRepository) UpdateLastLogin(id uint, at time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).UpdateColumn("last_login", at).Error
+}
+
+// FindAll 查詢使用者列表（分頁、過濾）
+func (r *UserRepository) FindAll(params *dto.UserQueryParams) ([]model.User, int64, error) {
+	var users []model.User
+	var total int64
+
+	query := r.db.Model(&model.User{})
+
+	// 過濾條件
+	if params.Role != "" {
+		query = query.Where("role = ?", params.Role)
+	}
+	if params.IsActive != nil {
+		query = query.Where("is_active = ?", *params.IsActive)
+	}
+	if params.Q != "" {
+		like := "%" + params.Q + "%"
+		query = query.Where("username ILIKE ? OR email ILIKE ? OR full_name ILIKE ?", like, like, like)
+	}
+
+	// 計算總數
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 分頁
+	offset := (params.Page - 1) * params.PageSize
+	err := query.Order("id ASC").
+		Offset(offset).
+		Limit(params.PageSize).
+		Find(&users).Error
+
+	return users, total, err
+}
+
+// Update 更新使用者
+func (r *UserRepository) Update(user *model.User) error {
+	return r.db.Save(user).Error
+}
+
+// Delete 軟刪除使用者
+func (r *UserRepository) Delete(id uint) error {
+	return r.db.Delete(&model.User{}, id).Error
+}
+
+// UsernameTaken 檢查使用者名稱是否已被使用（包含已刪除的使用者，因唯一索引仍會衝突）
+func (r *UserRepository) UsernameTaken(username string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&model.User{}).
+		Where("username = ? AND id <> ?", username, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// EmailTaken 檢查 Email 是否已被使用（包含已刪除的使用者）
+func (r *UserRepository) EmailTaken(email string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&model.User{}).
+		Where("LOWER(email) = LOWER(?) AND id <> ?", email, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountActiveAdmins 計算啟用中的管理員數量
+func (r *UserRepository) CountActiveAdmins() (int64, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).
+		Where("role = ? AND is_active = ?", "admin", true).
+		Count(&count).Error
+	return count, err
+}
+
+// CountAll 計算所有使用者數量（包含已刪除）
+func (r *UserRepository) CountAll() (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&model.User{}).Count(&count).Error
+	return count, err
+}
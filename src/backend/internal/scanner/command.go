@@ -0,0 +1,154 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+)
+
+const (
+	// TargetPlaceholder 參數中代表掃描目標的佔位符
+	TargetPlaceholder = "{target}"
+	// OutputPlaceholder 參數中代表輸出檔案的佔位符（未使用時改讀取 stdout）
+	OutputPlaceholder = "{output}"
+
+	// maxStderrBytes 錯誤訊息中保留的 stderr 長度上限
+	maxStderrBytes = 2048
+	// cancelGracePeriod 送出中斷訊號後等待工具結束的時間
+	cancelGracePeriod = 10 * time.Second
+)
+
+// CommandScanner 以外部執行檔實作的掃描器（nuclei、nmap、amass 等）
+type CommandScanner struct {
+	scanType string
+	binary   string
+	args     []string
+	parse    parser.Func
+}
+
+// NewCommandScanner 建立新的 CommandScanner
+//
+// args 為以空白分隔的參數字串，可使用 {target} 與 {output} 佔位符；支援 -- 的工具應將 {target} 放在 -- 之後；
+// parse 為 nil 時只執行工具而不產生結果。
+func NewCommandScanner(scanType, binary, args string, parse parser.Func) *CommandScanner {
+	return &CommandScanner{
+		scanType: scanType,
+		binary:   binary,
+		args:     strings.Fields(args),
+		parse:    parse,
+	}
+}
+
+// ScanType 回傳此掃描器負責的 scan_type
+func (s *CommandScanner) ScanType() string {
+	return s.scanType
+}
+
+// Scan 執行外部工具並解析其輸出
+func (s *CommandScanner) Scan(ctx context.Context, job *model.ScanJob) (*parser.Result, error) {
+	// 重試或既有的任務可能未經建立時的檢查，執行前再確認目標不會被解析為工具選項
+	if err := ValidateTarget(s.scanType, job.Target); err != nil {
+		return nil, fmt.Errorf("無效的掃描目標 %q: %w", job.Target, err)
+	}
+
+	outputPath := ""
+	if s.usesOutputFile() {
+		f, err := os.CreateTemp("", fmt.Sprintf("%s-%d-*.out", s.scanType, job.ID))
+		if err != nil {
+			return nil, fmt.Errorf("無法建立輸出檔案: %w", err)
+		}
+		outputPath = f.Name()
+		f.Close()
+		defer os.Remove(outputPath)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.binary, s.buildArgs(job.Target, outputPath)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// 取消時先送出中斷訊號讓工具寫完已收集的結果，逾時才強制終止
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = cancelGracePeriod
+
+	runErr := cmd.Run()
+	if runErr != nil && ctx.Err() == nil {
+		return nil, fmt.Errorf("%s 執行失敗: %w: %s", s.binary, runErr, tail(stderr.String(), maxStderrBytes))
+	}
+
+	// 被取消或逾時：回傳中止前已收集的部分結果
+	if ctx.Err() != nil {
+		result, err := s.parseOutput(&stdout, outputPath, true)
+		if err != nil {
+			result = &parser.Result{}
+		}
+		return result, context.Cause(ctx)
+	}
+
+	result, err := s.parseOutput(&stdout, outputPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s 輸出解析失敗: %w", s.scanType, err)
+	}
+	return result, nil
+}
+
+// parseOutput 解析 stdout 或輸出檔案；partial 為 true 時捨棄最後一行不完整的輸出
+func (s *CommandScanner) parseOutput(stdout *bytes.Buffer, outputPath string, partial bool) (*parser.Result, error) {
+	if s.parse == nil {
+		return &parser.Result{}, nil
+	}
+
+	output := stdout.Bytes()
+	if outputPath != "" {
+		content, err := os.ReadFile(outputPath)
+		if err != nil {
+			return nil, fmt.Errorf("無法讀取輸出檔案: %w", err)
+		}
+		output = content
+	}
+
+	if partial {
+		if i := bytes.LastIndexByte(output, '\n'); i >= 0 {
+			output = output[:i+1]
+		}
+	}
+
+	return s.parse(bytes.NewReader(output))
+}
+
+// usesOutputFile 判斷參數是否指定輸出檔案
+func (s *CommandScanner) usesOutputFile() bool {
+	for _, arg := range s.args {
+		if strings.Contains(arg, OutputPlaceholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildArgs 以實際值替換參數中的佔位符
+func (s *CommandScanner) buildArgs(target, outputPath string) []string {
+	replacer := strings.NewReplacer(TargetPlaceholder, target, OutputPlaceholder, outputPath)
+	args := make([]string, 0, len(s.args))
+	for _, arg := range s.args {
+		args = append(args, replacer.Replace(arg))
+	}
+	return args
+}
+
+// tail 取得字串結尾最多 n 個位元組
+func tail(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+)
+
+// fakeScanner 建立執行 testdata/fake-scanner.sh 的 CommandScanner；ready 為 hang 模式開始等待時建立的檔案
+func fakeScanner(t *testing.T, mode string) (s *CommandScanner, ready string) {
+	t.Helper()
+
+	ready = filepath.Join(t.TempDir(), "ready")
+	args := strings.Join([]string{"testdata/fake-scanner.sh", mode, TargetPlaceholder, ready}, " ")
+	return NewCommandScanner("nuclei", "sh", args, parser.Findings(parser.ParseNuclei)), ready
+}
+
+// waitForFile 等待檔案出現，回傳是否在時限內建立
+func waitForFile(path string) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestCommandScannerCompleted(t *testing.T) {
+	s, _ := fakeScanner(t, "complete")
+
+	result, err := s.Scan(context.Background(), &model.ScanJob{ID: 1, Target: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(result.Findings) != 2 {
+		t.Fatalf("findings = %d, want 2", len(result.Findings))
+	}
+	if f := result.Findings[1]; f.Severity != "medium" || f.Host != "example.com" {
+		t.Errorf("finding = %+v", f)
+	}
+}
+
+func TestCommandScannerFailed(t *testing.T) {
+	s, _ := fakeScanner(t, "fail")
+
+	result, err := s.Scan(context.Background(), &model.ScanJob{ID: 1, Target: "https://example.com"})
+	if err == nil {
+		t.Fatal("Scan should fail on a non-zero exit")
+	}
+	if result != nil {
+		t.Errorf("result = %+v, want nil", result)
+	}
+	if !strings.Contains(err.Error(), "exit status 2") || !strings.Contains(err.Error(), "Could not load templates") {
+		t.Errorf("err = %v, want exit status and stderr tail", err)
+	}
+}
+
+func TestCommandScannerCancelledKeepsPartialResults(t *testing.T) {
+	s, ready := fakeScanner(t, "hang")
+	errCancelled := errors.New("cancelled")
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		if !waitForFile(ready) {
+			t.Error("fake scanner did not start")
+		}
+		cancel(errCancelled)
+	}()
+
+	start := time.Now()
+	result, err := s.Scan(ctx, &model.ScanJob{ID: 1, Target: "https://example.com"})
+	if !errors.Is(err, errCancelled) {
+		t.Fatalf("err = %v, want cancellation cause", err)
+	}
+	if elapsed := time.Since(start); elapsed > cancelGracePeriod {
+		t.Errorf("Scan took %v, tool should exit on interrupt", elapsed)
+	}
+	// 最後一行不完整的輸出被捨棄
+	if len(result.Findings) != 1 || result.Findings[0].Title != "Git Config Disclosure" {
+		t.Errorf("findings = %+v, want the complete line only", result.Findings)
+	}
+}
+
+func TestCommandScannerTimeout(t *testing.T) {
+	s, _ := fakeScanner(t, "hang")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	result, err := s.Scan(ctx, &model.ScanJob{ID: 1, Target: "https://example.com"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Errorf("findings = %d, want 1 partial finding", len(result.Findings))
+	}
+}
+
+func TestCommandScannerRejectsOptionTarget(t *testing.T) {
+	s, _ := fakeScanner(t, "complete")
+
+	if _, err := s.Scan(context.Background(), &model.ScanJob{ID: 1, Target: "-u=file:///etc/passwd"}); err == nil {
+		t.Fatal("Scan should reject a target that starts with -")
+	}
+}
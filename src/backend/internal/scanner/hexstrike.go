@@ -0,0 +1,301 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/hexstrike"
+)
+
+// maxRawOutputBytes 無結構化解析器的工具保留於發現描述中的輸出長度上限
+const maxRawOutputBytes = 64 * 1024
+
+// hexStrikeTool HexStrike AI 工具的呼叫方式
+//
+// HexStrike AI 以 shell 組合指令列，因此只開放允許清單中的工具與參數；
+// additional_args 不接受使用者輸入，只使用 outputArgs 取得可解析的輸出。
+type hexStrikeTool struct {
+	targetParam string               // 目標參數名稱（預設 target）
+	targetKind  TargetKind           // 目標格式
+	params      map[string]paramRule // 使用者可指定的參數與其檢查規則
+	outputArgs  string               // 固定的 additional_args
+	parse       parser.Func          // 為 nil 時將原始輸出記錄為單一 info 發現
+}
+
+// paramRule 檢查單一工具參數的值
+type paramRule func(value interface{}) bool
+
+// hexStrikeTools 允許透過 HexStrike AI 執行的工具
+var hexStrikeTools = map[string]hexStrikeTool{
+	"nmap": {
+		targetKind: TargetNetwork,
+		params: map[string]paramRule{
+			"scan_type": oneOf("-sV", "-sCV", "-sT", "-sS", "-sU", "-sn"),
+			"ports":     matches(`^[0-9]{1,5}(-[0-9]{1,5})?(,[0-9]{1,5}(-[0-9]{1,5})?)*$`),
+		},
+		outputArgs: "-oX -",
+		parse:      parser.Findings(parser.ParseNmap),
+	},
+	"nuclei": {
+		targetKind: TargetURL,
+		params: map[string]paramRule{
+			"severity": matches(`^(critical|high|medium|low|info)(,(critical|high|medium|low|info))*$`),
+			"tags":     matches(`^[a-z0-9_-]+(,[a-z0-9_-]+)*$`),
+		},
+		outputArgs: "-jsonl -silent",
+		parse:      parser.Findings(parser.ParseNuclei),
+	},
+	"nikto":  {targetKind: TargetURL},
+	"wpscan": {targetParam: "url", targetKind: TargetURL},
+	"gobuster": {
+		targetParam: "url",
+		targetKind:  TargetURL,
+		params:      map[string]paramRule{"mode": oneOf("dir", "vhost")},
+	},
+	"dirsearch": {
+		targetParam: "url",
+		targetKind:  TargetURL,
+		params: map[string]paramRule{
+			"extensions": matches(`^[a-z0-9]{1,10}(,[a-z0-9]{1,10})*$`),
+			"recursive":  boolean,
+			"threads":    intRange(1, 50),
+		},
+	},
+	"feroxbuster": {
+		targetParam: "url",
+		targetKind:  TargetURL,
+		params:      map[string]paramRule{"threads": intRange(1, 50)},
+	},
+	"subfinder": {
+		targetParam: "domain",
+		targetKind:  TargetDomain,
+		params:      map[string]paramRule{"silent": boolean, "all_sources": boolean},
+	},
+	"amass": {targetParam: "domain", targetKind: TargetDomain},
+}
+
+// HexStrikeTools 取得允許透過 HexStrike AI 執行的工具
+func HexStrikeTools() []string {
+	tools := make([]string, 0, len(hexStrikeTools))
+	for tool := range hexStrikeTools {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// HexStrikeToolSupported 檢查工具是否在允許清單中
+func HexStrikeToolSupported(tool string) bool {
+	_, ok := hexStrikeTools[tool]
+	return ok
+}
+
+// ValidateHexStrikeTarget 依工具檢查掃描目標格式
+func ValidateHexStrikeTarget(tool, target string) error {
+	spec, ok := hexStrikeTools[tool]
+	if !ok {
+		return fmt.Errorf("不支援的 HexStrike 工具: %s", tool)
+	}
+	return validateTarget(spec.targetKind, target)
+}
+
+// ValidateHexStrikeParams 檢查工具參數只包含允許的參數且值符合規則
+func ValidateHexStrikeParams(tool string, params map[string]interface{}) error {
+	spec, ok := hexStrikeTools[tool]
+	if !ok {
+		return fmt.Errorf("不支援的 HexStrike 工具: %s", tool)
+	}
+	for name, value := range params {
+		rule, ok := spec.params[name]
+		if !ok {
+			return fmt.Errorf("%s 不支援參數 %s（允許：%s）", tool, name, strings.Join(spec.paramNames(), ", "))
+		}
+		if !rule(value) {
+			return fmt.Errorf("%s 參數 %s 的值無效: %v", tool, name, value)
+		}
+	}
+	return nil
+}
+
+// paramNames 取得工具允許的參數名稱
+func (t hexStrikeTool) paramNames() []string {
+	names := make([]string, 0, len(t.params))
+	for name := range t.params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// oneOf 參數值須為列出的字串之一
+func oneOf(values ...string) paramRule {
+	return func(value interface{}) bool {
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, v := range values {
+			if s == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matches 參數值須為符合格式的字串（最多 100 字元）
+func matches(pattern string) paramRule {
+	re := regexp.MustCompile(pattern)
+	return func(value interface{}) bool {
+		s, ok := value.(string)
+		return ok && len(s) <= 100 && re.MatchString(s)
+	}
+}
+
+// boolean 參數值須為布林值
+func boolean(value interface{}) bool {
+	_, ok := value.(bool)
+	return ok
+}
+
+// intRange 參數值須為範圍內的整數（JSON 數字解碼為 float64）
+func intRange(min, max int) paramRule {
+	return func(value interface{}) bool {
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n) && n >= float64(min) && n <= float64(max)
+	}
+}
+
+// HexStrikeScanner 交由 HexStrike AI 執行工具的掃描器（scan_type 為 hexstrike）
+// 工具與參數取自掃描任務的 metadata，只執行允許清單中的工具，nmap、nuclei 的輸出以對應的解析器轉換為發現
+type HexStrikeScanner struct {
+	client *hexstrike.Client
+}
+
+// NewHexStrikeScanner 建立新的 HexStrikeScanner
+func NewHexStrikeScanner(client *hexstrike.Client) *HexStrikeScanner {
+	return &HexStrikeScanner{client: client}
+}
+
+// ScanType 回傳此掃描器負責的 scan_type
+func (s *HexStrikeScanner) ScanType() string {
+	return model.ScanTypeHexStrike
+}
+
+// Scan 呼叫 HexStrike AI 執行工具並等待完成，再將輸出正規化為發現
+func (s *HexStrikeScanner) Scan(ctx context.Context, job *model.ScanJob) (*parser.Result, error) {
+	metadata, err := job.HexStrike()
+	if err != nil {
+		return nil, fmt.Errorf("無法解析 HexStrike 任務設定: %w", err)
+	}
+	if metadata.Tool == "" {
+		return nil, errors.New("HexStrike 任務未指定工具")
+	}
+
+	// 任務建立時已檢查，執行前再確認以涵蓋重試與既有的任務
+	spec, ok := hexStrikeTools[metadata.Tool]
+	if !ok {
+		return nil, fmt.Errorf("不支援的 HexStrike 工具: %s", metadata.Tool)
+	}
+	if err := validateTarget(spec.targetKind, job.Target); err != nil {
+		return nil, fmt.Errorf("無效的掃描目標 %q: %w", job.Target, err)
+	}
+	if err := ValidateHexStrikeParams(metadata.Tool, metadata.Params); err != nil {
+		return nil, err
+	}
+
+	result, err := s.client.RunTool(ctx, metadata.Tool, buildToolParams(spec, metadata.Params, job.Target))
+	if err != nil {
+		// 被取消或逾時：HexStrike AI 只在工具結束後回應，沒有可保留的部分結果
+		if ctx.Err() != nil {
+			return &parser.Result{}, context.Cause(ctx)
+		}
+		return nil, fmt.Errorf("HexStrike %s 執行失敗: %w", metadata.Tool, err)
+	}
+
+	parsed, err := normalizeToolResult(spec, metadata.Tool, job.Target, result)
+	if result.TimedOut {
+		// HexStrike AI 端逾時：保留能解析的部分結果並將任務標記為失敗
+		if err != nil {
+			parsed = &parser.Result{}
+		}
+		return parsed, fmt.Errorf("HexStrike %s 執行逾時", metadata.Tool)
+	}
+	// 部分工具發現問題時也會以非 0 結束，只有沒有任何輸出時才視為失敗
+	if !result.Success && strings.TrimSpace(result.Stdout) == "" {
+		return nil, fmt.Errorf("HexStrike %s 執行失敗（return code %d）: %s",
+			metadata.Tool, result.ReturnCode, tail(result.Stderr, maxStderrBytes))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("HexStrike %s 輸出解析失敗: %w", metadata.Tool, err)
+	}
+	return parsed, nil
+}
+
+// buildToolParams 組合工具參數：已檢查的使用者參數、目標與固定的 additional_args
+func buildToolParams(spec hexStrikeTool, params map[string]interface{}, target string) map[string]interface{} {
+	merged := make(map[string]interface{}, len(params)+2)
+	for k, v := range params {
+		if _, ok := spec.params[k]; ok {
+			merged[k] = v
+		}
+	}
+
+	targetParam := spec.targetParam
+	if targetParam == "" {
+		targetParam = "target"
+	}
+	merged[targetParam] = target
+
+	if spec.outputArgs != "" {
+		merged["additional_args"] = spec.outputArgs
+	}
+	return merged
+}
+
+// normalizeToolResult 以工具對應的解析器轉換輸出；無解析器時將原始輸出記錄為單一 info 發現
+func normalizeToolResult(spec hexStrikeTool, tool, target string, result *hexstrike.ToolResult) (*parser.Result, error) {
+	if spec.parse != nil {
+		return spec.parse(strings.NewReader(result.Stdout))
+	}
+
+	output := strings.TrimSpace(result.Stdout)
+	if output == "" {
+		return &parser.Result{}, nil
+	}
+
+	evidence, err := json.Marshal(map[string]interface{}{
+		"tool":           tool,
+		"return_code":    result.ReturnCode,
+		"execution_time": result.ExecutionTime,
+		"stderr":         tail(result.Stderr, maxStderrBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	finding := model.ScanFinding{
+		Severity:    "info",
+		Title:       fmt.Sprintf("HexStrike %s 執行結果", tool),
+		Description: head(output, maxRawOutputBytes),
+		Host:        target,
+		Evidence:    string(evidence),
+	}
+	return &parser.Result{Findings: []model.ScanFinding{finding}}, nil
+}
+
+// head 取得字串開頭最多 n 個位元組（去除被切斷的多位元組字元）
+func head(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dennislwm/unified-security-platform/backend/config"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/hexstrike"
+)
+
+const nmapXML = `<?xml version="1.0"?>
+<nmaprun scanner="nmap" start="1700000000">
+<host endtime="1700000100"><status state="up" reason="echo-reply"/>
+<address addr="10.0.0.1" addrtype="ipv4"/>
+<ports>
+<port protocol="tcp" portid="22"><state state="open" reason="syn-ack"/><service name="ssh" product="OpenSSH" version="8.9"/></port>
+<port protocol="tcp" portid="25"><state state="closed" reason="reset"/></port>
+</ports>
+</host>
+</nmaprun>`
+
+const nucleiJSONL = `{"template-id":"git-config","info":{"name":"Git Config Disclosure","severity":"medium"},"type":"http","host":"https://example.com","matched-at":"https://example.com/.git/config"}
+`
+
+// hexStrikeStub 模擬 HexStrike AI 的工具端點，記錄收到的路徑與參數
+type hexStrikeStub struct {
+	calls  int32
+	path   string
+	params map[string]interface{}
+	result hexstrike.ToolResult
+}
+
+func (h *hexStrikeStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&h.calls, 1)
+	h.path = r.URL.Path
+	json.NewDecoder(r.Body).Decode(&h.params)
+	json.NewEncoder(w).Encode(h.result)
+}
+
+// newHexStrikeScanner 建立指向 stub 的 HexStrikeScanner
+func newHexStrikeScanner(t *testing.T, stub *hexStrikeStub) *HexStrikeScanner {
+	t.Helper()
+
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	return NewHexStrikeScanner(hexstrike.NewClient(&config.ServicesConfig{HexStrikeURL: srv.URL}))
+}
+
+// hexStrikeJob 建立 hexstrike 掃描任務
+func hexStrikeJob(t *testing.T, target, tool string, params map[string]interface{}) *model.ScanJob {
+	t.Helper()
+
+	metadata, err := json.Marshal(model.HexStrikeMetadata{Tool: tool, Params: params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &model.ScanJob{Target: target, ScanType: model.ScanTypeHexStrike, Metadata: string(metadata)}
+}
+
+func TestHexStrikeScanNormalizesNmap(t *testing.T) {
+	stub := &hexStrikeStub{result: hexstrike.ToolResult{Success: true, Stdout: nmapXML}}
+	s := newHexStrikeScanner(t, stub)
+
+	result, err := s.Scan(context.Background(), hexStrikeJob(t, "10.0.0.1", "nmap", map[string]interface{}{"ports": "22,25"}))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if stub.path != "/api/tools/nmap" {
+		t.Errorf("path = %q", stub.path)
+	}
+	if stub.params["target"] != "10.0.0.1" || stub.params["ports"] != "22,25" || stub.params["additional_args"] != "-oX -" {
+		t.Errorf("params = %v", stub.params)
+	}
+
+	// 主機存活 + 一個開放埠
+	if len(result.Findings) != 2 {
+		t.Fatalf("findings = %d, want 2", len(result.Findings))
+	}
+	if port := result.Findings[1]; port.Port != 22 || port.Host != "10.0.0.1" || !strings.Contains(port.Title, "OpenSSH") {
+		t.Errorf("port finding = %+v", port)
+	}
+}
+
+func TestHexStrikeScanNormalizesNuclei(t *testing.T) {
+	// nuclei 發現問題時也可能以非 0 結束，有輸出就照常解析
+	stub := &hexStrikeStub{result: hexstrike.ToolResult{Success: false, ReturnCode: 1, Stdout: nucleiJSONL}}
+	s := newHexStrikeScanner(t, stub)
+
+	result, err := s.Scan(context.Background(), hexStrikeJob(t, "https://example.com", "nuclei", map[string]interface{}{"severity": "medium,high"}))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if stub.params["additional_args"] != "-jsonl -silent" || stub.params["severity"] != "medium,high" {
+		t.Errorf("params = %v", stub.params)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("findings = %d, want 1", len(result.Findings))
+	}
+	if f := result.Findings[0]; f.Severity != "medium" || f.Title != "Git Config Disclosure" {
+		t.Errorf("finding = %+v", f)
+	}
+}
+
+func TestHexStrikeScanRecordsRawOutput(t *testing.T) {
+	stub := &hexStrikeStub{result: hexstrike.ToolResult{Success: true, Stdout: "/admin (Status: 301)\n/login (Status: 200)\n", Stderr: "progress"}}
+	s := newHexStrikeScanner(t, stub)
+
+	result, err := s.Scan(context.Background(), hexStrikeJob(t, "https://example.com", "gobuster", map[string]interface{}{"mode": "dir"}))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if stub.params["url"] != "https://example.com" {
+		t.Errorf("params = %v, want target in url", stub.params)
+	}
+	if _, ok := stub.params["additional_args"]; ok {
+		t.Errorf("params = %v, additional_args should not be sent", stub.params)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("findings = %d, want 1", len(result.Findings))
+	}
+	f := result.Findings[0]
+	if f.Severity != "info" || f.Title != "HexStrike gobuster 執行結果" || !strings.Contains(f.Description, "/admin") || f.Host != "https://example.com" {
+		t.Errorf("finding = %+v", f)
+	}
+	if !strings.Contains(f.Evidence, `"stderr":"progress"`) {
+		t.Errorf("evidence = %s", f.Evidence)
+	}
+}
+
+func TestHexStrikeScanFailsWithoutOutput(t *testing.T) {
+	stub := &hexStrikeStub{result: hexstrike.ToolResult{Success: false, ReturnCode: 2, Stderr: "wordlist not found"}}
+	s := newHexStrikeScanner(t, stub)
+
+	_, err := s.Scan(context.Background(), hexStrikeJob(t, "https://example.com", "feroxbuster", nil))
+	if err == nil || !strings.Contains(err.Error(), "wordlist not found") {
+		t.Fatalf("err = %v, want failure with stderr", err)
+	}
+}
+
+func TestHexStrikeScanRejectsUnsafeRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		tool   string
+		params map[string]interface{}
+	}{
+		{"tool not allowed", "https://example.com", "sqlmap", nil},
+		{"additional_args", "https://example.com", "nuclei", map[string]interface{}{"additional_args": "-t /tmp/x.yaml"}},
+		{"unknown param", "10.0.0.1", "nmap", map[string]interface{}{"script": "vuln"}},
+		{"shell in param", "10.0.0.1", "nmap", map[string]interface{}{"ports": "80;id"}},
+		{"option in enum", "10.0.0.1", "nmap", map[string]interface{}{"scan_type": "-iL /etc/passwd"}},
+		{"wrong type", "https://example.com", "dirsearch", map[string]interface{}{"threads": "10"}},
+		{"out of range", "https://example.com", "dirsearch", map[string]interface{}{"threads": float64(500)}},
+		{"shell in target", "example.com;id", "amass", nil},
+		{"option as target", "-iL/etc/passwd", "nmap", nil},
+		{"url for domain tool", "https://example.com", "subfinder", nil},
+	}
+
+	stub := &hexStrikeStub{}
+	s := newHexStrikeScanner(t, stub)
+	for _, tt := range tests {
+		if _, err := s.Scan(context.Background(), hexStrikeJob(t, tt.target, tt.tool, tt.params)); err == nil {
+			t.Errorf("%s: Scan should fail", tt.name)
+		}
+	}
+	if n := atomic.LoadInt32(&stub.calls); n != 0 {
+		t.Errorf("calls = %d, want 0 (invalid requests must not reach HexStrike AI)", n)
+	}
+}
+
+func TestValidateHexStrikeParams(t *testing.T) {
+	valid := []struct {
+		tool   string
+		params map[string]interface{}
+	}{
+		{"nmap", map[string]interface{}{"scan_type": "-sCV", "ports": "1-1024,8080"}},
+		{"nuclei", map[string]interface{}{"tags": "cve,rce"}},
+		{"dirsearch", map[string]interface{}{"extensions": "php,html", "recursive": true, "threads": float64(20)}},
+		{"subfinder", map[string]interface{}{"silent": true, "all_sources": false}},
+		{"wpscan", nil},
+	}
+	for _, tt := range valid {
+		if err := ValidateHexStrikeParams(tt.tool, tt.params); err != nil {
+			t.Errorf("ValidateHexStrikeParams(%q, %v) = %v", tt.tool, tt.params, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+)
+
+// Scanner 掃描工具介面（每種 scan_type 對應一個實作）
+type Scanner interface {
+	// ScanType 回傳此掃描器負責的 scan_type
+	ScanType() string
+	// Scan 對掃描任務的目標執行掃描，回傳解析後的發現與資產
+	// ctx 被取消或逾時時，應回傳中止前已收集的部分結果以及 context.Cause(ctx)
+	Scan(ctx context.Context, job *model.ScanJob) (*parser.Result, error)
+}
+
+// Registry 掃描器註冊表
+type Registry struct {
+	mu       sync.RWMutex
+	scanners map[string]Scanner
+}
+
+// NewRegistry 建立新的 Registry
+func NewRegistry(scanners ...Scanner) *Registry {
+	r := &Registry{scanners: make(map[string]Scanner)}
+	for _, s := range scanners {
+		r.Register(s)
+	}
+	return r
+}
+
+// Register 註冊掃描器（相同 scan_type 會覆蓋舊的實作）
+func (r *Registry) Register(s Scanner) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scanners[s.ScanType()] = s
+}
+
+// Get 根據 scan_type 取得掃描器
+func (r *Registry) Get(scanType string) (Scanner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.scanners[scanType]
+	if !ok {
+		return nil, fmt.Errorf("不支援的掃描類型: %s", scanType)
+	}
+	return s, nil
+}
+
+// ScanTypes 取得所有已註冊的 scan_type
+func (r *Registry) ScanTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.scanners))
+	for t := range r.scanners {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TargetKind 掃描目標允許的格式
+type TargetKind int
+
+const (
+	// TargetAny 主機名稱、IP、CIDR、host:port 或 http(s) URL
+	TargetAny TargetKind = iota
+	// TargetNetwork 主機名稱、IP 或 CIDR（nmap）
+	TargetNetwork
+	// TargetURL http(s) URL、主機名稱、IP 或 host:port（nuclei）
+	TargetURL
+	// TargetDomain 網域名稱（amass、subfinder）
+	TargetDomain
+)
+
+// maxTargetLength 目標長度上限（與 scan_jobs.target 欄位一致）
+const maxTargetLength = 255
+
+// shellMetaChars 目標中不允許的字元（HexStrike AI 以 shell 組合指令，本機工具也不需要這些字元）
+const shellMetaChars = "`$;|&<>(){}'\"\\*!^~"
+
+// hostnamePattern 主機名稱（RFC 1123 標籤，以點分隔）
+var hostnamePattern = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// targetKinds 各 scan_type 的目標格式；未列出的類型為 TargetAny
+var targetKinds = map[string]TargetKind{
+	"nmap":   TargetNetwork,
+	"nuclei": TargetURL,
+	"amass":  TargetDomain,
+}
+
+// ValidateTarget 依 scan_type 檢查掃描目標格式
+// 目標會成為外部工具的參數，以 - 開頭或含空白、shell 特殊字元的值會被拒絕，避免被解析為工具選項
+func ValidateTarget(scanType, target string) error {
+	return validateTarget(targetKinds[scanType], target)
+}
+
+// validateTarget 檢查目標是否符合指定格式
+func validateTarget(kind TargetKind, target string) error {
+	switch {
+	case target == "":
+		return errors.New("目標不可為空")
+	case len(target) > maxTargetLength:
+		return fmt.Errorf("目標長度不可超過 %d 字元", maxTargetLength)
+	case strings.HasPrefix(target, "-"):
+		return errors.New("目標不可以 - 開頭")
+	case strings.IndexFunc(target, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0:
+		return errors.New("目標不可包含空白或控制字元")
+	case strings.ContainsAny(target, shellMetaChars):
+		return errors.New("目標不可包含 shell 特殊字元")
+	}
+
+	var ok bool
+	switch kind {
+	case TargetNetwork:
+		ok = isHost(target) || isCIDR(target)
+	case TargetURL:
+		ok = isURL(target) || isHost(target) || isHostPort(target)
+	case TargetDomain:
+		ok = net.ParseIP(target) == nil && isHostname(target) && strings.Contains(target, ".")
+	default:
+		ok = isHost(target) || isCIDR(target) || isHostPort(target) || isURL(target)
+	}
+	if !ok {
+		return fmt.Errorf("目標格式錯誤（須為%s）", kind.describe())
+	}
+	return nil
+}
+
+// describe 目標格式的說明
+func (k TargetKind) describe() string {
+	switch k {
+	case TargetNetwork:
+		return "主機名稱、IP 或 CIDR"
+	case TargetURL:
+		return "http(s) URL、主機名稱、IP 或 host:port"
+	case TargetDomain:
+		return "網域名稱"
+	default:
+		return "主機名稱、IP、CIDR、host:port 或 http(s) URL"
+	}
+}
+
+// isHostname 檢查是否為主機名稱
+func isHostname(s string) bool {
+	return len(s) <= 253 && hostnamePattern.MatchString(s)
+}
+
+// isHost 檢查是否為主機名稱或 IP
+func isHost(s string) bool {
+	return net.ParseIP(s) != nil || isHostname(s)
+}
+
+// isCIDR 檢查是否為 CIDR 網段
+func isCIDR(s string) bool {
+	_, _, err := net.ParseCIDR(s)
+	return err == nil
+}
+
+// isHostPort 檢查是否為 host:port（IPv6 需以中括號包住）
+func isHostPort(s string) bool {
+	host, port, err := net.SplitHostPort(s)
+	return err == nil && isHost(host) && validPort(port)
+}
+
+// isURL 檢查是否為不含帳密的 http(s) URL
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User != nil || !isHost(u.Hostname()) {
+		return false
+	}
+	return u.Port() == "" || validPort(u.Port())
+}
+
+// validPort 檢查埠號範圍
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 1 && n <= 65535
+}
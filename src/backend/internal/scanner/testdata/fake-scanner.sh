@@ -0,0 +1,37 @@
+#!/bin/sh
+# 模擬 nuclei 的掃描工具（測試用）：fake-scanner.sh <mode> <target> [ready-file]
+#   complete  輸出兩筆 JSONL 結果後結束
+#   fail      輸出錯誤訊息到 stderr 並以 2 結束
+#   hang      輸出一筆完整與一筆不完整的結果，建立 ready-file 後等待中斷訊號
+mode=$1
+target=$2
+
+finding() {
+	printf '{"template-id":"%s","info":{"name":"%s","severity":"%s"},"host":"%s","matched-at":"%s"}\n' \
+		"$1" "$2" "$3" "$target" "$target"
+}
+
+case "$mode" in
+complete)
+	finding tech-detect "Tech Detect" info
+	finding git-config "Git Config Disclosure" medium
+	;;
+fail)
+	finding tech-detect "Tech Detect" info
+	echo "[FTL] Could not load templates: no such file" >&2
+	exit 2
+	;;
+hang)
+	finding git-config "Git Config Disclosure" medium
+	printf '{"template-id":"trunc'
+	sleep 30 &
+	pid=$!
+	trap 'kill $pid; exit 130' INT TERM
+	touch "$3"
+	wait $pid
+	;;
+*)
+	echo "unknown mode: $mode" >&2
+	exit 64
+	;;
+esac
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+// lastUsedInterval 最後使用時間的更新間隔，避免每個請求都寫入資料庫
+const lastUsedInterval = time.Minute
+
+var (
+	// ErrAPIKeyNotFound API 金鑰不存在
+	ErrAPIKeyNotFound = apperror.NotFound("api_key_not_found", "API 金鑰不存在")
+	// ErrInvalidAPIKey API 金鑰無效
+	ErrInvalidAPIKey = apperror.Unauthorized("invalid_api_key", "無效的 API 金鑰")
+	// ErrAPIKeyRevoked API 金鑰已撤銷
+	ErrAPIKeyRevoked = apperror.Unauthorized("api_key_revoked", "API 金鑰已撤銷")
+	// ErrAPIKeyExpired API 金鑰已過期
+	ErrAPIKeyExpired = apperror.Unauthorized("api_key_expired", "API 金鑰已過期")
+	// ErrScopeNotAllowed 要求的 scope 超出使用者角色的權限
+	ErrScopeNotAllowed = apperror.Forbidden("scope_not_allowed", "API 金鑰的權限範圍不可超出使用者角色的權限")
+	// ErrInvalidExpiry 過期時間必須在未來
+	ErrInvalidExpiry = apperror.Validation("invalid_expiry", "過期時間必須晚於現在")
+)
+
+// APIKeyService API 金鑰業務邏輯層
+type APIKeyService struct {
+	repo *repository.APIKeyRepository
+}
+
+// NewAPIKeyService 建立新的 APIKeyService
+func NewAPIKeyService(repo *repository.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{repo: repo}
+}
+
+// CreateAPIKey 為使用者建立 API 金鑰；scopes 只能是使用者角色已具備的權限
+func (s *APIKeyService) CreateAPIKey(user *model.User, req *dto.CreateAPIKeyRequest) (*vo.CreatedAPIKeyResponse, error) {
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{string(auth.PermissionRead)}
+	}
+	for _, scope := range scopes {
+		if !auth.ValidPermission(scope) || !auth.Allowed(user, auth.Permission(scope)) {
+			return nil, ErrScopeNotAllowed.WithDetails(map[string]interface{}{"scope": scope})
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidExpiry
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &model.APIKey{
+		UserID:    user.ID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    "{" + strings.Join(unique(scopes), ",") + "}",
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(apiKey); err != nil {
+		return nil, err
+	}
+
+	return &vo.CreatedAPIKeyResponse{
+		APIKeyResponse: vo.FromAPIKey(apiKey),
+		Key:            key,
+	}, nil
+}
+
+// GetAPIKeys 取得使用者的 API 金鑰列表
+func (s *APIKeyService) GetAPIKeys(user *model.User) ([]vo.APIKeyResponse, error) {
+	keys, err := s.repo.FindByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]vo.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, vo.FromAPIKey(&keys[i]))
+	}
+	return responses, nil
+}
+
+// RevokeAPIKey 撤銷使用者的 API 金鑰（重複撤銷不會改變撤銷時間）
+func (s *APIKeyService) RevokeAPIKey(user *model.User, id uint) error {
+	key, err := s.repo.FindByIDForUser(id, user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+	if key.IsRevoked() {
+		return nil
+	}
+	return s.repo.Revoke(key.ID, time.Now())
+}
+
+// Authenticate 驗證 API 金鑰並取得所屬使用者（供認證中間件使用）
+func (s *APIKeyService) Authenticate(key string) (*model.User, *model.APIKey, error) {
+	if !strings.HasPrefix(key, auth.APIKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repo.FindByHash(auth.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if apiKey.IsRevoked() {
+		return nil, nil, ErrAPIKeyRevoked
+	}
+	if apiKey.IsExpired(now) {
+		return nil, nil, ErrAPIKeyExpired
+	}
+	if apiKey.User == nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if !apiKey.User.IsActive {
+		return nil, nil, ErrAccountDisabled
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedInterval {
+		if err := s.repo.TouchLastUsed(apiKey.ID, now); err != nil {
+			return nil, nil, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey.User, apiKey, nil
+}
+
+// unique 去除重複值並保留原順序
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
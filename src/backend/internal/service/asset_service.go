@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+// ErrAssetNotFound 資產不存在
+var ErrAssetNotFound = apperror.NotFound("asset_not_found", "資產不存在")
+
+// AssetService 資產業務邏輯層
+type AssetService struct {
+	repo *repository.AssetRepository
+}
+
+// NewAssetService 建立新的 AssetService
+func NewAssetService(repo *repository.AssetRepository) *AssetService {
+	return &AssetService{repo: repo}
+}
+
+// GetAssetByID 根據 ID 取得資產
+func (s *AssetService) GetAssetByID(id uint) (*vo.AssetResponse, error) {
+	asset, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, err
+	}
+
+	response := vo.FromAsset(asset)
+	return &response, nil
+}
+
+// GetAssets 取得資產列表（分頁、搜尋）
+func (s *AssetService) GetAssets(params *dto.AssetQueryParams) (*vo.PaginatedResponse, error) {
+	// 設定預設值
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.PageSize == 0 {
+		params.PageSize = 20
+	}
+
+	// 從資料庫查詢
+	assets, total, err := s.repo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 轉換為 VO
+	assetResponses := make([]vo.AssetResponse, 0, len(assets))
+	for i := range assets {
+		assetResponses = append(assetResponses, vo.FromAsset(&assets[i]))
+	}
+
+	// 計算總頁數
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize != 0 {
+		totalPages++
+	}
+
+	return &vo.PaginatedResponse{
+		Data:       assetResponses,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalCount: total,
+		TotalPages: totalPages,
+	}, nil
+}
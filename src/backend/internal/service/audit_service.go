@@ -0,0 +1,50 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+)
+
+// AuditService 稽核紀錄業務邏輯層
+type AuditService struct {
+	repo *repository.AuditRepository
+}
+
+// NewAuditService 建立新的 AuditService
+func NewAuditService(repo *repository.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// AuditRequest 產生稽核紀錄所需的請求資訊
+type AuditRequest struct {
+	Method   string
+	Path     string
+	ClientIP string
+}
+
+// Record 寫入一筆稽核紀錄；details 會序列化為 JSON
+func (s *AuditService) Record(user *model.User, action, outcome string, req AuditRequest, details map[string]interface{}) error {
+	entry := &model.AuditLog{
+		Action:   action,
+		Outcome:  outcome,
+		Method:   req.Method,
+		Path:     req.Path,
+		ClientIP: req.ClientIP,
+	}
+	if user != nil {
+		entry.UserID = &user.ID
+		entry.Username = user.Username
+		entry.Role = user.Role
+	}
+	if len(details) > 0 {
+		b, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		entry.Details = string(b)
+	}
+
+	return s.repo.Create(entry)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/redis"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// revokedTokenPrefix 已撤銷 token 的 Redis key 前綴（後接 jti）
+const revokedTokenPrefix = "auth:revoked:"
+
+var (
+	// ErrInvalidCredentials 帳號或密碼錯誤
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "帳號或密碼錯誤")
+	// ErrAccountDisabled 帳號已停用
+	ErrAccountDisabled = apperror.Forbidden("account_disabled", "帳號已停用")
+	// ErrInvalidToken token 無效或已過期
+	ErrInvalidToken = apperror.Unauthorized("invalid_token", "無效或已過期的 token")
+	// ErrTokenRevoked token 已被撤銷（已登出）
+	ErrTokenRevoked = apperror.Unauthorized("token_revoked", "token 已撤銷")
+)
+
+// AuthService 認證業務邏輯層
+type AuthService struct {
+	users  *repository.UserRepository
+	tokens *auth.TokenManager
+	redis  *redis.Client
+
+	// dummyHash 使用者不存在時仍執行 bcrypt 比對，避免以回應時間探測帳號
+	dummyHash []byte
+}
+
+// NewAuthService 建立新的 AuthService
+func NewAuthService(users *repository.UserRepository, tokens *auth.TokenManager, redisClient *redis.Client) *AuthService {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("unified-security-platform"), bcrypt.DefaultCost)
+	return &AuthService{
+		users:     users,
+		tokens:    tokens,
+		redis:     redisClient,
+		dummyHash: dummyHash,
+	}
+}
+
+// Login 驗證帳號密碼並簽發 token，成功時更新最後登入時間
+func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*vo.TokenResponse, error) {
+	user, err := s.users.FindByLogin(req.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			bcrypt.CompareHashAndPassword(s.dummyHash, []byte(req.Password))
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if !auth.CheckPassword(user.PasswordHash, req.Password) {
+		return nil, ErrInvalidCredentials
+	}
+	if !user.IsActive {
+		return nil, ErrAccountDisabled
+	}
+
+	now := time.Now()
+	if err := s.users.UpdateLastLogin(user.ID, now); err != nil {
+		return nil, err
+	}
+	user.LastLogin = &now
+
+	return s.issue(user)
+}
+
+// Refresh 以 refresh token 換發新的 token 組合；舊的 refresh token 會被撤銷
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*vo.TokenResponse, error) {
+	claims, err := s.tokens.Parse(refreshToken, auth.TokenTypeRefresh)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.activeUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.revoke(ctx, claims); err != nil {
+		return nil, err
+	}
+	return s.issue(user)
+}
+
+// Logout 撤銷目前的 access token；提供 refresh token 時一併撤銷
+func (s *AuthService) Logout(ctx context.Context, accessClaims *auth.Claims, refreshToken string) error {
+	if err := s.revoke(ctx, accessClaims); err != nil {
+		return err
+	}
+	if refreshToken == "" {
+		return nil
+	}
+
+	claims, err := s.tokens.Parse(refreshToken, auth.TokenTypeRefresh)
+	if err != nil || claims.Subject != accessClaims.Subject {
+		return ErrInvalidToken
+	}
+	return s.revoke(ctx, claims)
+}
+
+// Authenticate 驗證 access token 並取得對應的使用者（供認證中間件使用）
+func (s *AuthService) Authenticate(ctx context.Context, accessToken string) (*model.User, *auth.Claims, error) {
+	claims, err := s.tokens.Parse(accessToken, auth.TokenTypeAccess)
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	user, err := s.activeUser(ctx, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, claims, nil
+}
+
+// activeUser 確認 token 未被撤銷，並取得仍為啟用狀態的使用者
+func (s *AuthService) activeUser(ctx context.Context, claims *auth.Claims) (*model.User, error) {
+	revoked, err := s.redis.Exists(ctx, revokedTokenPrefix+claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked > 0 {
+		return nil, ErrTokenRevoked
+	}
+
+	id, err := claims.UserID()
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	user, err := s.users.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrAccountDisabled
+	}
+
+	// 變更密碼前簽發的 token 一律失效（JWT 時間精度為秒）
+	if user.PasswordChangedAt != nil && claims.IssuedAt != nil &&
+		claims.IssuedAt.Time.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return nil, ErrTokenRevoked
+	}
+	return user, nil
+}
+
+// revoke 將 token 的 jti 寫入 Redis，保留到 token 原本的過期時間
+func (s *AuthService) revoke(ctx context.Context, claims *auth.Claims) error {
+	ttl := time.Second
+	if claims.ExpiresAt != nil {
+		if remaining := time.Until(claims.ExpiresAt.Time); remaining > ttl {
+			ttl = remaining
+		}
+	}
+	return s.redis.Set(ctx, revokedTokenPrefix+claims.ID, "1", ttl)
+}
+
+// issue 簽發 token 並轉換為回應
+func (s *AuthService) issue(user *model.User) (*vo.TokenResponse, error) {
+	pair, err := s.tokens.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &vo.TokenResponse{
+		AccessToken:      pair.AccessToken,
+		RefreshToken:     pair.RefreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(pair.AccessExpiresAt.Sub(now).Seconds()),
+		RefreshExpiresIn: int64(pair.RefreshExpiresAt.Sub(now).Seconds()),
+		User:             vo.FromUser(user),
+	}, nil
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrFindingNotFound 掃描發現不存在
+	ErrFindingNotFound = apperror.NotFound("finding_not_found", "掃描發現不存在")
+	// ErrJustificationRequired 誤報與風險接受需附理由
+	ErrJustificationRequired = apperror.Validation("justification_required", "標記為誤報或接受風險時必須提供理由")
+	// ErrRiskExpiryRequired 風險接受需有未來的到期時間
+	ErrRiskExpiryRequired = apperror.Validation("risk_expiry_required", "接受風險時必須提供晚於現在的到期時間")
+	// ErrEmptyTriage 分流請求未包含任何欄位
+	ErrEmptyTriage = apperror.Validation("empty_triage", "至少需提供 triage_status、assignee、justification 或 risk_accepted_until 其中一項")
+	// ErrInvalidCursor 分頁 cursor 無效或與排序方式不符
+	ErrInvalidCursor = apperror.Validation("invalid_cursor", "無效的分頁 cursor，或與目前的排序方式不符")
+	// ErrInvalidSeverity 嚴重程度不合法
+	ErrInvalidSeverity = apperror.Validation("invalid_severity", "嚴重程度必須是 critical、high、medium、low、info")
+	// ErrInvalidCVSSRange CVSS 範圍下限大於上限
+	ErrInvalidCVSSRange = apperror.Validation("invalid_cvss_range", "cvss_min 不可大於 cvss_max")
+)
+
+// FindingService 掃描發現分流業務邏輯層
+type FindingService struct {
+	repo  *repository.FindingRepository
+	users *repository.UserRepository
+}
+
+// NewFindingService 建立新的 FindingService
+func NewFindingService(repo *repository.FindingRepository, users *repository.UserRepository) *FindingService {
+	return &FindingService{repo: repo, users: users}
+}
+
+// TriageFinding 更新單一掃描發現的分流狀態、指派對象、理由與風險接受到期時間
+func (s *FindingService) TriageFinding(actor *model.User, id uint, req *dto.TriageFindingRequest) (*vo.ScanFindingResponse, error) {
+	finding, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFindingNotFound
+		}
+		return nil, err
+	}
+
+	findings := []model.ScanFinding{*finding}
+	if err := s.triage(actor, findings, req); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromScanFinding(&findings[0])
+	return &response, nil
+}
+
+// BulkTriage 以相同的分流內容更新多筆掃描發現；任何一筆不存在或不合法時全部不更新
+func (s *FindingService) BulkTriage(actor *model.User, req *dto.BulkTriageFindingsRequest) (*vo.BulkTriageResponse, error) {
+	ids := uniqueIDs(req.IDs)
+	findings, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(findings) != len(ids) {
+		found := make(map[uint]bool, len(findings))
+		for _, finding := range findings {
+			found[finding.ID] = true
+		}
+		missing := make([]uint, 0)
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		return nil, ErrFindingNotFound.WithDetails(map[string]interface{}{"missing_ids": missing})
+	}
+
+	if err := s.triage(actor, findings, &req.TriageFindingRequest); err != nil {
+		return nil, err
+	}
+
+	responses := make([]vo.ScanFindingResponse, 0, len(findings))
+	for i := range findings {
+		responses = append(responses, vo.FromScanFinding(&findings[i]))
+	}
+	return &vo.BulkTriageResponse{
+		Updated:  len(findings),
+		Findings: responses,
+	}, nil
+}
+
+// triage 套用分流內容、檢查規則並寫入資料庫
+func (s *FindingService) triage(actor *model.User, findings []model.ScanFinding, req *dto.TriageFindingRequest) error {
+	if req.TriageStatus == nil && req.Assignee == nil && req.Justification == nil && req.RiskAcceptedUntil == nil {
+		return ErrEmptyTriage
+	}
+	// 只變更指派對象時不重新檢查既有的分流內容（例如已過期的風險接受）
+	reclassified := req.TriageStatus != nil || req.Justification != nil || req.RiskAcceptedUntil != nil
+
+	var assignee string
+	if req.Assignee != nil {
+		var err error
+		if assignee, err = resolveAssignee(s.users, *req.Assignee); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for i := range findings {
+		finding := &findings[i]
+		if req.TriageStatus != nil {
+			finding.TriageStatus = *req.TriageStatus
+		}
+		if req.Assignee != nil {
+			finding.Assignee = assignee
+		}
+		if req.Justification != nil {
+			finding.Justification = strings.TrimSpace(*req.Justification)
+		}
+		if req.RiskAcceptedUntil != nil {
+			finding.RiskAcceptedUntil = req.RiskAcceptedUntil
+		}
+		if finding.TriageStatus != model.TriageStatusAcceptedRisk {
+			finding.RiskAcceptedUntil = nil
+		}
+
+		if reclassified {
+			if err := validateTriage(finding, now); err != nil {
+				return err
+			}
+		}
+		finding.TriagedBy = actor.Username
+		finding.TriagedAt = &now
+	}
+
+	return s.repo.SaveTriage(findings)
+}
+
+// validateTriage 檢查誤報與風險接受的必要欄位
+func validateTriage(finding *model.ScanFinding, now time.Time) error {
+	switch finding.TriageStatus {
+	case model.TriageStatusFalsePositive, model.TriageStatusAcceptedRisk:
+		if finding.Justification == "" {
+			return ErrJustificationRequired.WithDetails(map[string]interface{}{"finding_id": finding.ID})
+		}
+	}
+	if finding.TriageStatus == model.TriageStatusAcceptedRisk &&
+		(finding.RiskAcceptedUntil == nil || !finding.RiskAcceptedUntil.After(now)) {
+		return ErrRiskExpiryRequired.WithDetails(map[string]interface{}{"finding_id": finding.ID})
+	}
+	return nil
+}
+
+// uniqueIDs 去除重複 ID 並保留原順序
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// GetFindings 跨掃描查詢發現（keyset 分頁）
+// 多取一筆判斷是否還有下一頁，next_cursor 編碼本頁最後一筆的排序鍵與排序方式
+func (s *FindingService) GetFindings(params *dto.FindingQueryParams) (*vo.CursorResponse, error) {
+	// 設定預設值
+	if params.Limit == 0 {
+		params.Limit = 50
+	}
+	if params.Sort == "" {
+		params.Sort = "severity"
+	}
+	if params.Order == "" {
+		params.Order = "desc"
+	}
+
+	severities, err := parseSeverities(params.Severity)
+	if err != nil {
+		return nil, err
+	}
+	if params.CVSSMin != nil && params.CVSSMax != nil && *params.CVSSMin > *params.CVSSMax {
+		return nil, ErrInvalidCVSSRange
+	}
+
+	var cursor *repository.FindingCursor
+	if params.Cursor != "" {
+		if cursor, err = decodeFindingCursor(params.Cursor, params.Sort, params.Order); err != nil {
+			return nil, err
+		}
+	}
+
+	findings, err := s.repo.FindAll(params, severities, cursor, params.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(findings) > params.Limit
+	if hasMore {
+		findings = findings[:params.Limit]
+	}
+
+	findingResponses := make([]vo.ScanFindingResponse, 0, len(findings))
+	for i := range findings {
+		findingResponses = append(findingResponses, vo.FromScanFinding(&findings[i]))
+	}
+
+	response := &vo.CursorResponse{
+		Data:    findingResponses,
+		Limit:   params.Limit,
+		HasMore: hasMore,
+	}
+	if hasMore {
+		last := &findings[len(findings)-1]
+		response.NextCursor = encodeFindingCursor(last, params.Sort, params.Order)
+	}
+	return response, nil
+}
+
+// findingCursorPayload 編碼在 cursor 中的內容（排序方式不同時 cursor 無效）
+type findingCursorPayload struct {
+	repository.FindingCursor
+	Sort  string `json:"o"`
+	Order string `json:"d"`
+}
+
+// encodeFindingCursor 以發現的排序鍵建立 cursor
+func encodeFindingCursor(finding *model.ScanFinding, sort, order string) string {
+	cvss := -1.0
+	if finding.CVSSScore != nil {
+		cvss = *finding.CVSSScore
+	}
+	payload, _ := json.Marshal(findingCursorPayload{
+		FindingCursor: repository.FindingCursor{Score: finding.SeverityScore(), CVSS: cvss, ID: finding.ID},
+		Sort:          sort,
+		Order:         order,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeFindingCursor 解析 cursor 並確認與目前的排序方式一致
+func decodeFindingCursor(cursor, sort, order string) (*repository.FindingCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload findingCursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	if payload.Sort != sort || payload.Order != order {
+		return nil, ErrInvalidCursor.WithDetails(map[string]interface{}{"sort": payload.Sort, "order": payload.Order})
+	}
+	return &payload.FindingCursor, nil
+}
+
+// parseSeverities 解析嚴重程度集合（支援逗號分隔與重複參數）
+func parseSeverities(values []string) ([]string, error) {
+	severities := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, severity := range strings.Split(value, ",") {
+			severity = strings.ToLower(strings.TrimSpace(severity))
+			if severity == "" {
+				continue
+			}
+			if model.SeverityScore(severity) == 0 {
+				return nil, ErrInvalidSeverity.WithDetails(map[string]interface{}{"severity": severity})
+			}
+			severities = append(severities, severity)
+		}
+	}
+	return unique(severities), nil
+}
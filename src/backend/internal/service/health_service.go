@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/buildinfo"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/database"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/redis"
+	"gorm.io/gorm"
+)
+
+// 健康狀態
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusDegraded    = "degraded"
+	HealthStatusUnavailable = "unavailable"
+)
+
+const (
+	// serviceName 健康檢查回報的服務名稱
+	serviceName = "unified-security-platform-backend"
+	// dependencyCheckTimeout 單一依賴檢查的逾時時間
+	dependencyCheckTimeout = 2 * time.Second
+)
+
+// dependencyCheck 依賴檢查；required 的依賴失敗時服務視為未就緒
+// check 回傳的 details 與錯誤只寫入日誌，不出現在回應中
+type dependencyCheck struct {
+	name     string
+	required bool
+	check    func(ctx context.Context) (map[string]interface{}, error)
+}
+
+// HealthService 存活與就緒檢查業務邏輯層
+type HealthService struct {
+	checks []dependencyCheck
+	client *http.Client
+	log    *logger.Logger
+}
+
+// NewHealthService 建立新的 HealthService
+// 資料庫、結構版本與 Redis 為必要依賴；HexStrike AI 與 AI/量子服務為選用依賴（URL 為空時略過）
+func NewHealthService(db *gorm.DB, redisClient *redis.Client, migrator *database.Migrator, hexStrikeURL, aiQuantumURL string,
+	log *logger.Logger) *HealthService {
+	s := &HealthService{client: &http.Client{Timeout: dependencyCheckTimeout}, log: log}
+	s.checks = []dependencyCheck{
+		{name: "database", required: true, check: databaseCheck(db)},
+		{name: "migrations", required: true, check: migrationCheck(db, migrator)},
+		{name: "redis", required: true, check: func(ctx context.Context) (map[string]interface{}, error) {
+			return nil, redisClient.Ping(ctx)
+		}},
+	}
+	if hexStrikeURL != "" {
+		s.checks = append(s.checks, dependencyCheck{name: "hexstrike", check: s.httpCheck(hexStrikeURL)})
+	}
+	if aiQuantumURL != "" {
+		s.checks = append(s.checks, dependencyCheck{name: "ai_quantum", check: s.httpCheck(aiQuantumURL)})
+	}
+	return s
+}
+
+// Liveness 存活檢查：只確認程序可回應，不檢查依賴
+func (s *HealthService) Liveness() *vo.HealthResponse {
+	return newHealthResponse(HealthStatusOK)
+}
+
+// Readiness 就緒檢查：同時檢查所有依賴並回報各自的狀態與耗時
+// 必要依賴失敗時為 unavailable（ready 為 false），僅選用依賴失敗時為 degraded；失敗原因記錄於日誌
+func (s *HealthService) Readiness(ctx context.Context) (*vo.HealthResponse, bool) {
+	results := make([]vo.DependencyStatus, len(s.checks))
+
+	var wg sync.WaitGroup
+	for i, dep := range s.checks {
+		wg.Add(1)
+		go func(i int, dep dependencyCheck) {
+			defer wg.Done()
+			results[i] = s.runCheck(ctx, dep)
+		}(i, dep)
+	}
+	wg.Wait()
+
+	status := HealthStatusOK
+	for _, result := range results {
+		if result.Status == HealthStatusOK {
+			continue
+		}
+		if result.Required {
+			status = HealthStatusUnavailable
+			break
+		}
+		status = HealthStatusDegraded
+	}
+
+	response := newHealthResponse(status)
+	response.Checks = results
+	return response, status != HealthStatusUnavailable
+}
+
+// runCheck 在逾時限制內執行依賴檢查並記錄耗時；失敗時將錯誤與 details 寫入日誌
+func (s *HealthService) runCheck(ctx context.Context, dep dependencyCheck) vo.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+	defer cancel()
+
+	type outcome struct {
+		details map[string]interface{}
+		err     error
+	}
+	done := make(chan outcome, 1)
+
+	start := time.Now()
+	go func() {
+		details, err := dep.check(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = fmt.Errorf("檢查逾時（%s）", dependencyCheckTimeout)
+	}
+
+	status := vo.DependencyStatus{
+		Name:      dep.name,
+		Status:    HealthStatusOK,
+		Required:  dep.required,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if result.err != nil {
+		status.Status = HealthStatusUnavailable
+		s.log.Warn("⚠️ 依賴檢查失敗",
+			"dependency", dep.name, "required", dep.required, "error", result.err, "details", result.details)
+	}
+	return status
+}
+
+// databaseCheck 檢查資料庫連線並回報連線池狀態（失敗時寫入日誌）
+func databaseCheck(db *gorm.DB) func(ctx context.Context) (map[string]interface{}, error) {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		// 連線池狀態有助於判斷失敗是否來自連線耗盡
+		stats := sqlDB.Stats()
+		details := map[string]interface{}{
+			"open_connections": stats.OpenConnections,
+			"in_use":           stats.InUse,
+			"idle":             stats.Idle,
+		}
+		return details, sqlDB.PingContext(ctx)
+	}
+}
+
+// migrationCheck 檢查資料庫結構版本不落後於二進位檔內嵌的最新遷移
+// 先確認連線可用，避免連線失敗時被誤判為尚未套用任何遷移
+func migrationCheck(db *gorm.DB, migrator *database.Migrator) func(ctx context.Context) (map[string]interface{}, error) {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return nil, err
+		}
+
+		current, err := migrator.Version()
+		if err != nil {
+			return nil, err
+		}
+
+		latest := migrator.LatestVersion()
+		details := map[string]interface{}{"current": current, "latest": latest}
+		if current < latest {
+			return details, fmt.Errorf("資料庫結構版本落後（%d < %d），請執行 migrate up", current, latest)
+		}
+		return details, nil
+	}
+}
+
+// httpCheck 以 GET {baseURL}/health 檢查外部服務可連線（5xx 視為失敗）
+func (s *HealthService) httpCheck(baseURL string) func(ctx context.Context) (map[string]interface{}, error) {
+	url := strings.TrimRight(baseURL, "/") + "/health"
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+
+		details := map[string]interface{}{"url": url, "status_code": resp.StatusCode}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return details, fmt.Errorf("服務回應 %d", resp.StatusCode)
+		}
+		return details, nil
+	}
+}
+
+// newHealthResponse 建立包含建置資訊的健康檢查回應
+func newHealthResponse(status string) *vo.HealthResponse {
+	return &vo.HealthResponse{
+		Status:    status,
+		Service:   serviceName,
+		Version:   buildinfo.Version,
+		Time:      time.Now().Format(time.RFC3339),
+		Commit:    buildinfo.Commit,
+		BuildTime: buildinfo.BuildTime,
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/scanner"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/hexstrike"
+	"gorm.io/gorm"
+)
+
+// hexStrikePollInterval 等待掃描結束時查詢任務狀態的間隔
+const hexStrikePollInterval = time.Second
+
+var (
+	// ErrInvalidHexStrikeTool 工具不在允許清單中
+	ErrInvalidHexStrikeTool = apperror.Validation("invalid_tool", "不支援的 HexStrike 工具")
+	// ErrInvalidHexStrikeParams 工具參數不在允許清單中或值無效
+	ErrInvalidHexStrikeParams = apperror.Validation("invalid_tool_params", "無效的 HexStrike 工具參數")
+	// ErrHexStrikeUnavailable HexStrike AI 連續失敗，斷路器開啟中
+	ErrHexStrikeUnavailable = apperror.Unavailable("hexstrike_unavailable", "HexStrike AI 暫時無法使用，請稍後再試")
+)
+
+// HexStrikeService HexStrike AI 整合業務邏輯層
+// 建立 hexstrike 掃描任務後由背景 worker 呼叫 HexStrike AI 執行並寫入發現
+type HexStrikeService struct {
+	repo    *repository.ScanRepository
+	scans   *ScanService
+	client  *hexstrike.Client
+	maxWait time.Duration
+}
+
+// NewHexStrikeService 建立新的 HexStrikeService；maxWait 為單次請求等待掃描結束的上限
+func NewHexStrikeService(repo *repository.ScanRepository, scans *ScanService, client *hexstrike.Client, maxWait time.Duration) *HexStrikeService {
+	return &HexStrikeService{repo: repo, scans: scans, client: client, maxWait: maxWait}
+}
+
+// CreateScan 建立 hexstrike 掃描任務；wait 大於 0 時輪詢至任務結束或等待逾時
+// 回傳任務詳情（含發現統計）與任務是否已結束
+func (s *HexStrikeService) CreateScan(ctx context.Context, req *dto.HexStrikeScanRequest, wait time.Duration) (*vo.ScanJobDetailResponse, bool, error) {
+	if !scanner.HexStrikeToolSupported(req.Tool) {
+		return nil, false, ErrInvalidHexStrikeTool.WithDetails(map[string]interface{}{"tool": req.Tool, "supported": scanner.HexStrikeTools()})
+	}
+	if err := scanner.ValidateHexStrikeTarget(req.Tool, req.Target); err != nil {
+		return nil, false, ErrInvalidTarget.Wrap(err).WithDetails(map[string]interface{}{"target": req.Target, "tool": req.Tool})
+	}
+	if err := scanner.ValidateHexStrikeParams(req.Tool, req.Params); err != nil {
+		return nil, false, ErrInvalidHexStrikeParams.Wrap(err).WithDetails(map[string]interface{}{"tool": req.Tool})
+	}
+	// 斷路器開啟時直接拒絕，避免建立必定失敗的任務
+	if s.client.CircuitOpen() {
+		return nil, false, ErrHexStrikeUnavailable
+	}
+
+	metadata, err := json.Marshal(model.HexStrikeMetadata{Tool: req.Tool, Params: req.Params})
+	if err != nil {
+		return nil, false, ErrInvalidHexStrikeParams.Wrap(err)
+	}
+
+	scan := &model.ScanJob{
+		Target:   req.Target,
+		ScanType: model.ScanTypeHexStrike,
+		Status:   model.ScanStatusPending,
+		Metadata: string(metadata),
+	}
+	if err := s.repo.Create(scan); err != nil {
+		return nil, false, err
+	}
+
+	if wait > s.maxWait {
+		wait = s.maxWait
+	}
+	done := false
+	if wait > 0 {
+		if done, err = s.await(ctx, scan.ID, wait); err != nil {
+			return nil, false, err
+		}
+	}
+
+	detail, err := s.scans.GetScanByID(scan.ID, &dto.ScanDetailParams{Summary: true})
+	if err != nil {
+		return nil, false, err
+	}
+	return detail, done, nil
+}
+
+// await 輪詢掃描任務直到結束、等待逾時或請求被取消，回傳任務是否已結束（逾時與取消不視為錯誤）
+func (s *HexStrikeService) await(ctx context.Context, id uint, wait time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	ticker := time.NewTicker(hexStrikePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-ticker.C:
+		}
+
+		scan, err := s.repo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, ErrScanNotFound
+			}
+			return false, err
+		}
+		if scan.IsTerminal() {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+// ErrIssueNotFound 問題不存在
+var ErrIssueNotFound = apperror.NotFound("issue_not_found", "問題不存在")
+
+// IssueService 跨掃描問題業務邏輯層
+type IssueService struct {
+	repo *repository.IssueRepository
+}
+
+// NewIssueService 建立新的 IssueService
+func NewIssueService(repo *repository.IssueRepository) *IssueService {
+	return &IssueService{repo: repo}
+}
+
+// GetIssues 取得問題列表（分頁、過濾）
+func (s *IssueService) GetIssues(params *dto.IssueQueryParams) (*vo.PaginatedResponse, error) {
+	// 設定預設值
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.PageSize == 0 {
+		params.PageSize = 20
+	}
+
+	issues, total, err := s.repo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	issueResponses := make([]vo.IssueResponse, 0, len(issues))
+	for i := range issues {
+		issueResponses = append(issueResponses, vo.FromIssue(&issues[i]))
+	}
+
+	// 計算總頁數
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize != 0 {
+		totalPages++
+	}
+
+	return &vo.PaginatedResponse{
+		Data:       issueResponses,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalCount: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetIssueByID 根據 ID 取得問題
+func (s *IssueService) GetIssueByID(id uint) (*vo.IssueResponse, error) {
+	issue, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrIssueNotFound
+		}
+		return nil, err
+	}
+
+	response := vo.FromIssue(issue)
+	return &response, nil
+}
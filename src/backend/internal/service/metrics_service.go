@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/redis"
+)
+
+const (
+	// summaryCacheTTL 指標摘要快取時間
+	summaryCacheTTL = 30 * time.Second
+	// summaryCachePrefix 指標摘要快取 key 前綴（後接快取版本與查詢參數）
+	summaryCachePrefix = "metrics:summary:"
+	// summaryVersionKey 指標摘要快取版本；掃描結果寫入時遞增，使既有快取失效
+	summaryVersionKey = "metrics:summary:version"
+)
+
+// maxTrendBuckets 趨勢查詢最多的時間區間數量
+const maxTrendBuckets = 400
+
+var (
+	// threatEventTypes 計入「已阻擋威脅」的安全事件類型
+	threatEventTypes = []string{"intrusion", "threat"}
+	// trendSeverities 趨勢中發現數量的嚴重程度（依嚴重程度排序）
+	trendSeverities = []string{"critical", "high", "medium", "low", "info"}
+	// highRiskSeverities 趨勢中追蹤開放數量的高風險嚴重程度
+	highRiskSeverities = []string{"critical", "high"}
+)
+
+var (
+	// ErrInvalidTimeRange 時間範圍的起始時間不早於結束時間
+	ErrInvalidTimeRange = apperror.Validation("invalid_time_range", "from 必須早於 to")
+	// ErrTooManyBuckets 時間範圍切分出的區間過多
+	ErrTooManyBuckets = apperror.Validation("too_many_buckets", fmt.Sprintf("時間範圍最多切分為 %d 個區間，請縮小範圍或改用較大的 interval", maxTrendBuckets))
+)
+
+// MetricsService 平台指標（摘要、趨勢）業務邏輯層
+type MetricsService struct {
+	scanRepo  *repository.ScanRepository
+	issueRepo *repository.IssueRepository
+	eventRepo *repository.SecurityEventRepository
+	redis     *redis.Client
+}
+
+// NewMetricsService 建立新的 MetricsService
+func NewMetricsService(scanRepo *repository.ScanRepository, issueRepo *repository.IssueRepository,
+	eventRepo *repository.SecurityEventRepository, redisClient *redis.Client) *MetricsService {
+	return &MetricsService{scanRepo: scanRepo, issueRepo: issueRepo, eventRepo: eventRepo, redis: redisClient}
+}
+
+// GetSummary 取得平台指標摘要（優先使用 Redis 快取，Redis 無法使用時直接計算）
+// 掃描與發現依掃描任務的建立時間過濾，安全事件依事件的建立時間過濾
+func (s *MetricsService) GetSummary(ctx context.Context, params *dto.MetricsSummaryParams) (*vo.MetricsResponse, error) {
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, ErrInvalidTimeRange
+	}
+	// 未要求目標明細時忽略 target_limit，避免不同查詢共用同一個快取 key
+	switch {
+	case !params.ByTarget:
+		params.TargetLimit = 0
+	case params.TargetLimit == 0:
+		params.TargetLimit = 10
+	}
+
+	key := s.cacheKey(ctx, params)
+	if cached, err := s.redis.Get(ctx, key); err == nil {
+		var response vo.MetricsResponse
+		if json.Unmarshal([]byte(cached), &response) == nil {
+			return &response, nil
+		}
+	}
+
+	response, err := s.summarize(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 快取寫入失敗不影響回應
+	if data, err := json.Marshal(response); err == nil {
+		s.redis.Set(ctx, key, data, summaryCacheTTL)
+	}
+	return response, nil
+}
+
+// GetTrends 依 day、week、month 區間（UTC）統計掃描、發現、問題與安全事件的趨勢
+// 未指定時間範圍時取最近 30 天、12 週或 12 個月；from 會對齊到所在區間的起點
+// 目標與掃描類型過濾套用於掃描、發現與問題，安全事件不受影響
+func (s *MetricsService) GetTrends(params *dto.TrendQueryParams) (*vo.TrendResponse, error) {
+	// 設定預設值
+	if params.Interval == "" {
+		params.Interval = "day"
+	}
+	to := time.Now().UTC()
+	if params.To != nil {
+		to = params.To.UTC()
+	}
+	var from time.Time
+	if params.From != nil {
+		from = params.From.UTC()
+	} else {
+		switch params.Interval {
+		case "week":
+			from = to.AddDate(0, 0, -7*11)
+		case "month":
+			from = to.AddDate(0, -11, 0)
+		default:
+			from = to.AddDate(0, 0, -29)
+		}
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	buckets := trendBuckets(params.Interval, from, to)
+	if len(buckets) > maxTrendBuckets {
+		return nil, ErrTooManyBuckets.WithDetails(map[string]interface{}{"buckets": len(buckets)})
+	}
+
+	filter := &repository.TrendFilter{
+		Interval: params.Interval,
+		From:     buckets[0].Start,
+		To:       to,
+		Target:   params.Target,
+		ScanType: params.ScanType,
+	}
+
+	response := &vo.TrendResponse{
+		Interval: params.Interval,
+		From:     filter.From,
+		To:       to,
+		Target:   params.Target,
+		ScanType: params.ScanType,
+		Buckets:  make([]vo.TrendBucket, 0, len(buckets)),
+	}
+	index := make(map[int64]*vo.TrendBucket, len(buckets))
+	for _, b := range buckets {
+		bucket := vo.TrendBucket{
+			Start:              b.Start,
+			End:                b.End,
+			FindingsDiscovered: make(map[string]int64, len(trendSeverities)),
+			OpenHighRisk:       make(map[string]int64, len(highRiskSeverities)),
+		}
+		for _, severity := range trendSeverities {
+			bucket.FindingsDiscovered[severity] = 0
+		}
+		for _, severity := range highRiskSeverities {
+			bucket.OpenHighRisk[severity] = 0
+		}
+		response.Buckets = append(response.Buckets, bucket)
+	}
+	for i := range response.Buckets {
+		index[response.Buckets[i].Start.Unix()] = &response.Buckets[i]
+	}
+
+	scans, err := s.scanRepo.ScanTrend(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range scans {
+		if bucket, ok := index[c.Bucket.Unix()]; ok {
+			switch c.Name {
+			case "started":
+				bucket.ScansStarted = c.Count
+			case model.ScanStatusCompleted:
+				bucket.ScansCompleted = c.Count
+			case model.ScanStatusFailed:
+				bucket.ScansFailed = c.Count
+			}
+		}
+	}
+
+	findings, err := s.scanRepo.FindingTrend(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range findings {
+		if bucket, ok := index[c.Bucket.Unix()]; ok {
+			bucket.FindingsDiscovered[c.Name] = c.Count
+		}
+	}
+
+	open, err := s.issueRepo.OpenTrend(filter, buckets, highRiskSeverities)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range open {
+		if bucket, ok := index[c.Bucket.Unix()]; ok {
+			bucket.OpenHighRisk[c.Name] = c.Count
+		}
+	}
+
+	resolved, err := s.issueRepo.ResolvedTrend(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range resolved {
+		if bucket, ok := index[r.Bucket.Unix()]; ok {
+			mttr := r.MTTRHours
+			bucket.IssuesResolved = r.Count
+			bucket.MTTRHours = &mttr
+		}
+	}
+
+	events, err := s.eventRepo.EventTrend(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range events {
+		if bucket, ok := index[c.Bucket.Unix()]; ok {
+			switch c.Name {
+			case "opened":
+				bucket.EventsOpened = c.Count
+			case model.EventStatusResolved:
+				bucket.EventsResolved = c.Count
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// ObserveFindings 掃描發現寫入後使指標摘要快取失效（實作 ScanObserver）
+func (s *MetricsService) ObserveFindings(scan *model.ScanJob, findings []model.ScanFinding) {
+	if len(findings) > 0 {
+		s.invalidate()
+	}
+}
+
+// ObserveScanFinished 掃描任務結束後使指標摘要快取失效（實作 ScanObserver）
+func (s *MetricsService) ObserveScanFinished(scan *model.ScanJob) {
+	s.invalidate()
+}
+
+// summarize 從資料庫計算指標摘要
+func (s *MetricsService) summarize(params *dto.MetricsSummaryParams) (*vo.MetricsResponse, error) {
+	byStatus, err := s.scanRepo.CountBy("status", params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+	byType, err := s.scanRepo.CountBy("scan_type", params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+	bySeverity, err := s.scanRepo.CountAllFindingsBySeverity(params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+	eventsByStatus, err := s.eventRepo.CountBy("status", params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+	threatsBlocked, err := s.eventRepo.CountResolvedByType(threatEventTypes, params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &vo.MetricsResponse{
+		ScansTotal:     sumCounts(byStatus),
+		EventsTotal:    sumCounts(eventsByStatus),
+		ThreatsBlocked: threatsBlocked,
+		ByType:         byType,
+		BySeverity:     bySeverity,
+
+		ByStatus:      byStatus,
+		FindingsTotal: sumCounts(bySeverity),
+		From:          params.From,
+		To:            params.To,
+		GeneratedAt:   &now,
+	}
+
+	if params.ByTarget {
+		targets, err := s.scanRepo.TargetBreakdown(params.From, params.To, params.TargetLimit)
+		if err != nil {
+			return nil, err
+		}
+		response.ByTarget = make([]vo.TargetMetrics, 0, len(targets))
+		for _, t := range targets {
+			response.ByTarget = append(response.ByTarget, vo.TargetMetrics{
+				Target:     t.Target,
+				ScansTotal: t.Scans,
+				Findings:   t.Findings,
+				HighRisk:   t.HighRisk,
+				LastScanAt: t.LastScanAt,
+			})
+		}
+	}
+	return response, nil
+}
+
+// cacheKey 依快取版本與查詢參數組成快取 key（版本不存在時為 0）
+func (s *MetricsService) cacheKey(ctx context.Context, params *dto.MetricsSummaryParams) string {
+	version, err := s.redis.Get(ctx, summaryVersionKey)
+	if err != nil {
+		version = "0"
+	}
+	return fmt.Sprintf("%s%s:%s:%s:%t:%d", summaryCachePrefix, version,
+		formatTimeParam(params.From), formatTimeParam(params.To), params.ByTarget, params.TargetLimit)
+}
+
+// invalidate 遞增快取版本，使既有的指標摘要快取不再被讀取（舊快取隨 TTL 過期）
+func (s *MetricsService) invalidate() {
+	s.redis.Incr(context.Background(), summaryVersionKey)
+}
+
+// trendBuckets 將 [from, to) 切分為對齊 UTC 的區間（週以星期一為起點，與 date_trunc 一致）
+func trendBuckets(interval string, from, to time.Time) []repository.TrendBucket {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case "week":
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	case "month":
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	var buckets []repository.TrendBucket
+	for start.Before(to) && len(buckets) <= maxTrendBuckets {
+		var end time.Time
+		switch interval {
+		case "week":
+			end = start.AddDate(0, 0, 7)
+		case "month":
+			end = start.AddDate(0, 1, 0)
+		default:
+			end = start.AddDate(0, 0, 1)
+		}
+		buckets = append(buckets, repository.TrendBucket{Start: start, End: end})
+		start = end
+	}
+	return buckets
+}
+
+// formatTimeParam 將可選的時間參數格式化為快取 key 片段
+func formatTimeParam(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// sumCounts 加總統計數量
+func sumCounts(counts map[string]int64) int64 {
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNoPreviousScan 找不到可比較的先前掃描
+	ErrNoPreviousScan = apperror.NotFound("no_previous_scan", "找不到同一目標與掃描類型的先前已完成掃描")
+	// ErrDiffSameScan 不可與自己比較
+	ErrDiffSameScan = apperror.Validation("diff_same_scan", "不可與同一個掃描任務比較")
+	// ErrDiffTargetMismatch 兩個掃描任務的目標或掃描類型不同
+	ErrDiffTargetMismatch = apperror.Validation("diff_target_mismatch", "只能比較同一目標與掃描類型的掃描任務")
+)
+
+// DiffScans 比較掃描任務與另一次掃描（against 為 0 時使用同一目標與類型的前一次已完成掃描）
+// 以發現指紋比對：只出現在本次的為新增，只出現在比較對象的為已解決，兩者皆有的為未變更或嚴重程度改變
+func (s *ScanService) DiffScans(id, against uint) (*vo.ScanDiffResponse, error) {
+	scan, err := s.repo.FindByIDWithFindings(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScanNotFound
+		}
+		return nil, err
+	}
+
+	var base *model.ScanJob
+	if against == 0 {
+		base, err = s.repo.FindPreviousCompleted(scan)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrNoPreviousScan
+			}
+			return nil, err
+		}
+	} else {
+		if against == id {
+			return nil, ErrDiffSameScan
+		}
+		base, err = s.repo.FindByIDWithFindings(against)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrScanNotFound.WithDetails(map[string]interface{}{"scan_id": against})
+			}
+			return nil, err
+		}
+		// 不同工具的指紋規則不同，跨類型比較會把所有發現誤判為新增與已解決
+		if base.Target != scan.Target || base.ScanType != scan.ScanType {
+			return nil, ErrDiffTargetMismatch.WithDetails(map[string]interface{}{
+				"target": scan.Target, "against_target": base.Target,
+				"scan_type": scan.ScanType, "against_scan_type": base.ScanType,
+			})
+		}
+	}
+
+	current := findingsByFingerprint(scan)
+	previous := findingsByFingerprint(base)
+
+	diff := &vo.ScanDiffResponse{
+		ScanID:          scan.ID,
+		AgainstID:       base.ID,
+		Target:          scan.Target,
+		ScanType:        scan.ScanType,
+		New:             make([]vo.ScanFindingResponse, 0),
+		Resolved:        make([]vo.ScanFindingResponse, 0),
+		Unchanged:       make([]vo.ScanFindingResponse, 0),
+		SeverityChanged: make([]vo.SeverityChange, 0),
+	}
+	for _, fp := range sortedFingerprints(current) {
+		finding := current[fp]
+		prev, ok := previous[fp]
+		switch {
+		case !ok:
+			diff.New = append(diff.New, vo.FromScanFinding(finding))
+		case prev.Severity != finding.Severity:
+			diff.SeverityChanged = append(diff.SeverityChanged, vo.SeverityChange{
+				From:    prev.Severity,
+				To:      finding.Severity,
+				Finding: vo.FromScanFinding(finding),
+			})
+		default:
+			diff.Unchanged = append(diff.Unchanged, vo.FromScanFinding(finding))
+		}
+	}
+	for _, fp := range sortedFingerprints(previous) {
+		if _, ok := current[fp]; !ok {
+			diff.Resolved = append(diff.Resolved, vo.FromScanFinding(previous[fp]))
+		}
+	}
+
+	diff.Summary = vo.ScanDiffSummary{
+		New:             len(diff.New),
+		Resolved:        len(diff.Resolved),
+		Unchanged:       len(diff.Unchanged),
+		SeverityChanged: len(diff.SeverityChanged),
+	}
+	return diff, nil
+}
+
+// DiffMarkdown 將掃描差異轉為 Markdown 摘要
+func DiffMarkdown(diff *vo.ScanDiffResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 掃描差異：#%d 對比 #%d\n\n", diff.ScanID, diff.AgainstID)
+	fmt.Fprintf(&b, "- 目標：`%s`（%s）\n", diff.Target, diff.ScanType)
+	fmt.Fprintf(&b, "- 新增 %d、已解決 %d、嚴重程度改變 %d、未變更 %d\n",
+		diff.Summary.New, diff.Summary.Resolved, diff.Summary.SeverityChanged, diff.Summary.Unchanged)
+
+	writeFindings := func(title string, findings []vo.ScanFindingResponse) {
+		fmt.Fprintf(&b, "\n## %s（%d）\n\n", title, len(findings))
+		if len(findings) == 0 {
+			b.WriteString("無\n")
+			return
+		}
+		b.WriteString("| 嚴重程度 | 標題 | 位置 | CVE |\n|---|---|---|---|\n")
+		for _, f := range findings {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", f.Severity, markdownCell(f.Title), markdownCell(findingLocation(f)), f.CVEID)
+		}
+	}
+
+	writeFindings("新增", diff.New)
+	writeFindings("已解決", diff.Resolved)
+
+	fmt.Fprintf(&b, "\n## 嚴重程度改變（%d）\n\n", len(diff.SeverityChanged))
+	if len(diff.SeverityChanged) == 0 {
+		b.WriteString("無\n")
+	} else {
+		b.WriteString("| 變更 | 標題 | 位置 | CVE |\n|---|---|---|---|\n")
+		for _, c := range diff.SeverityChanged {
+			fmt.Fprintf(&b, "| %s → %s | %s | %s | %s |\n", c.From, c.To,
+				markdownCell(c.Finding.Title), markdownCell(findingLocation(c.Finding)), c.Finding.CVEID)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n未變更的發現共 %d 筆。\n", diff.Summary.Unchanged)
+	return b.String()
+}
+
+// findingsByFingerprint 依指紋整理掃描發現；同一指紋有多筆時保留嚴重程度最高者
+// 沒有指紋的舊資料會即時計算指紋
+func findingsByFingerprint(scan *model.ScanJob) map[string]*model.ScanFinding {
+	result := make(map[string]*model.ScanFinding, len(scan.Findings))
+	for i := range scan.Findings {
+		finding := &scan.Findings[i]
+		fp := finding.Fingerprint
+		if fp == "" {
+			fp = parser.Fingerprint(scan.ScanType, finding)
+		}
+		if existing, ok := result[fp]; !ok || finding.SeverityScore() > existing.SeverityScore() {
+			result[fp] = finding
+		}
+	}
+	return result
+}
+
+// sortedFingerprints 依嚴重程度（高到低）、主機、埠號與標題排序指紋，讓輸出穩定
+func sortedFingerprints(findings map[string]*model.ScanFinding) []string {
+	keys := make([]string, 0, len(findings))
+	for fp := range findings {
+		keys = append(keys, fp)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := findings[keys[i]], findings[keys[j]]
+		if a.SeverityScore() != b.SeverityScore() {
+			return a.SeverityScore() > b.SeverityScore()
+		}
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// findingLocation 組合發現的主機與埠號
+func findingLocation(f vo.ScanFindingResponse) string {
+	if f.Port > 0 {
+		return fmt.Sprintf("%s:%d", f.Host, f.Port)
+	}
+	return f.Host
+}
+
+// markdownCell 跳脫 Markdown 表格儲存格中的直線與換行
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
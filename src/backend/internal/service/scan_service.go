@@ -1,33 +1,108 @@
 package service
 
 import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"time"
 
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
 	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
 	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
 	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/scanner"
 	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrScanNotFound 掃描任務不存在
+	ErrScanNotFound = apperror.NotFound("scan_not_found", "掃描任務不存在")
+	// ErrInvalidResults 上傳的掃描結果無法解析
+	ErrInvalidResults = apperror.Validation("invalid_results", "掃描結果格式錯誤")
+	// ErrResultsTooLarge 上傳的掃描結果超過大小上限
+	ErrResultsTooLarge = apperror.Validation("results_too_large", "掃描結果超過大小上限")
+	// ErrInvalidTarget 掃描目標格式不符合掃描類型
+	ErrInvalidTarget = apperror.Validation("invalid_target", "掃描目標格式錯誤")
+	// ErrScanCancelled 掃描任務已被使用者取消（作為 context 取消原因）
+	ErrScanCancelled = errors.New("掃描任務已取消")
+)
+
+// newWorkerManagedError 建立狀態只能由 worker 設定的錯誤
+func newWorkerManagedError(id uint, from, to string) error {
+	return apperror.Conflict("worker_managed_status",
+		fmt.Sprintf("掃描任務 %d 的 %s 狀態由背景 worker 設定，無法手動轉換為 %s；中止請使用 POST /scans/%d/cancel", id, to, to, id)).
+		WithDetails(map[string]interface{}{"scan_id": id, "from": from, "to": to})
+}
+
+// newScanNotFinishedError 建立掃描任務尚未結束而無法執行 action 的錯誤
+func newScanNotFinishedError(id uint, status, action string) error {
+	return apperror.Conflict("scan_not_finished",
+		fmt.Sprintf("掃描任務 %d 尚未結束（%s），無法%s；請等待掃描結束或使用 POST /scans/%d/cancel 取消", id, status, action, id)).
+		WithDetails(map[string]interface{}{"scan_id": id, "status": status})
+}
+
+// newTransitionError 建立不允許的掃描任務狀態轉換錯誤
+func newTransitionError(id uint, from, to string) error {
+	return apperror.Conflict("invalid_transition", fmt.Sprintf("掃描任務 %d 無法從 %s 轉換為 %s", id, from, to)).
+		WithDetails(map[string]interface{}{"scan_id": id, "from": from, "to": to})
+}
+
+// ScanCanceller 通知正在執行掃描的元件中止指定的掃描任務
+type ScanCanceller interface {
+	Cancel(id uint) bool
+}
+
+// ScanObserver 接收掃描結果寫入與掃描結束的通知（通常為 Prometheus 指標）
+type ScanObserver interface {
+	ObserveFindings(scan *model.ScanJob, findings []model.ScanFinding)
+	ObserveScanFinished(scan *model.ScanJob)
+}
+
 // ScanService 掃描業務邏輯層
 type ScanService struct {
-	repo *repository.ScanRepository
+	repo        *repository.ScanRepository
+	assetRepo   *repository.AssetRepository
+	findingRepo *repository.FindingRepository
+	issueRepo   *repository.IssueRepository
+	canceller   ScanCanceller
+	observers   []ScanObserver
 }
 
 // NewScanService 建立新的 ScanService
-func NewScanService(repo *repository.ScanRepository) *ScanService {
-	return &ScanService{repo: repo}
+func NewScanService(repo *repository.ScanRepository, assetRepo *repository.AssetRepository,
+	findingRepo *repository.FindingRepository, issueRepo *repository.IssueRepository) *ScanService {
+	return &ScanService{repo: repo, assetRepo: assetRepo, findingRepo: findingRepo, issueRepo: issueRepo}
+}
+
+// SetCanceller 設定取消掃描時要通知的元件（通常為背景 worker）
+func (s *ScanService) SetCanceller(canceller ScanCanceller) {
+	s.canceller = canceller
+}
+
+// AddObserver 加入掃描結果寫入與掃描結束時要通知的元件
+func (s *ScanService) AddObserver(observer ScanObserver) {
+	s.observers = append(s.observers, observer)
 }
 
 // CreateScan 建立新的掃描任務
 func (s *ScanService) CreateScan(req *dto.CreateScanRequest) (*vo.ScanJobResponse, error) {
+	// 目標會成為外部工具的參數，建立前先依掃描類型檢查格式
+	if err := scanner.ValidateTarget(req.ScanType, req.Target); err != nil {
+		return nil, ErrInvalidTarget.Wrap(err).WithDetails(map[string]interface{}{"target": req.Target, "scan_type": req.ScanType})
+	}
+
 	// 建立 Model
 	scan := &model.ScanJob{
 		Target:   req.Target,
 		ScanType: req.ScanType,
-		Status:   "pending",
+		Status:   model.ScanStatusPending,
 	}
 
 	// 儲存到資料庫
@@ -40,22 +115,90 @@ func (s *ScanService) CreateScan(req *dto.CreateScanRequest) (*vo.ScanJobRespons
 	return &response, nil
 }
 
-// GetScanByID 根據 ID 取得掃描任務
-func (s *ScanService) GetScanByID(id uint) (*vo.ScanJobDetailResponse, error) {
+// GetScanByID 根據 ID 取得掃描任務，附上發現統計與分頁後的發現
+// summary 模式只回傳各嚴重程度的統計，不查詢發現本身
+func (s *ScanService) GetScanByID(id uint, params *dto.ScanDetailParams) (*vo.ScanJobDetailResponse, error) {
+	// 設定預設值
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.PageSize == 0 {
+		params.PageSize = 100
+	}
+
+	severities, err := parseSeverities(params.Severity)
+	if err != nil {
+		return nil, err
+	}
+
 	// 從資料庫查詢
-	scan, err := s.repo.FindByIDWithFindings(id)
+	scan, err := s.repo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("掃描任務不存在")
+			return nil, ErrScanNotFound
+		}
+		return nil, err
+	}
+
+	counts, err := s.repo.CountFindingsBySeverity(scan.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary := summarizeFindings(counts)
+
+	if params.Summary {
+		response := vo.ScanJobDetailResponse{
+			ScanJobResponse: vo.FromScanJob(scan),
+			FindingsSummary: summary,
 		}
+		return &response, nil
+	}
+
+	findings, total, err := s.repo.FindFindings(scan.ID, severities, (params.Page-1)*params.PageSize, params.PageSize)
+	if err != nil {
 		return nil, err
 	}
+	scan.Findings = findings
+
+	// 計算總頁數
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize != 0 {
+		totalPages++
+	}
 
 	// 轉換為 VO 並返回
 	response := vo.FromScanJobWithFindings(scan)
+	response.FindingsSummary = summary
+	response.FindingsPage = &vo.PageInfo{
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalCount: total,
+		TotalPages: totalPages,
+	}
 	return &response, nil
 }
 
+// summarizeFindings 將各嚴重程度的數量整理為統計（依 SeverityScore 由高到低，IsHighRisk 計入高風險）
+func summarizeFindings(counts map[string]int64) *vo.FindingsSummary {
+	summary := &vo.FindingsSummary{BySeverity: make([]vo.SeverityCount, 0, len(counts))}
+	for severity, count := range counts {
+		finding := model.ScanFinding{Severity: severity}
+		summary.Total += count
+		if finding.IsHighRisk() {
+			summary.HighRisk += count
+		}
+		summary.BySeverity = append(summary.BySeverity, vo.SeverityCount{
+			Severity: severity,
+			Score:    finding.SeverityScore(),
+			Count:    count,
+		})
+	}
+	sort.Slice(summary.BySeverity, func(i, j int) bool {
+		return summary.BySeverity[i].Score > summary.BySeverity[j].Score
+	})
+	return summary
+}
+
 // GetScans 取得掃描任務列表（分頁）
 func (s *ScanService) GetScans(params *dto.ScanQueryParams) (*vo.PaginatedResponse, error) {
 	// 設定預設值
@@ -91,50 +234,582 @@ func (s *ScanService) GetScans(params *dto.ScanQueryParams) (*vo.PaginatedRespon
 		PageSize:   params.PageSize,
 		TotalCount: total,
 		TotalPages: totalPages,
+
+		TotalEstimated: params.Estimate,
+	}
+
+	return response, nil
+}
+
+// GetScansByCursor 以 keyset 分頁取得掃描任務列表（建立時間新到舊）
+// 多取一筆判斷是否還有下一頁；params.Estimate 時附上估計總數
+func (s *ScanService) GetScansByCursor(params *dto.ScanQueryParams) (*vo.CursorResponse, error) {
+	// 設定預設值
+	if params.Limit == 0 {
+		params.Limit = 10
+	}
+
+	var cursor *repository.ScanCursor
+	if params.Cursor != "" {
+		var err error
+		if cursor, err = decodeScanCursor(params.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	scans, err := s.repo.FindAfter(params, cursor, params.Limit+1)
+	if err != nil {
+		return nil, err
 	}
 
+	hasMore := len(scans) > params.Limit
+	if hasMore {
+		scans = scans[:params.Limit]
+	}
+
+	scanResponses := make([]vo.ScanJobResponse, 0, len(scans))
+	for i := range scans {
+		scanResponses = append(scanResponses, vo.FromScanJob(&scans[i]))
+	}
+
+	response := &vo.CursorResponse{
+		Data:    scanResponses,
+		Limit:   params.Limit,
+		HasMore: hasMore,
+	}
+	if hasMore {
+		last := &scans[len(scans)-1]
+		response.NextCursor = encodeScanCursor(last)
+	}
+	if params.Estimate {
+		total, err := s.repo.EstimateCount(params)
+		if err != nil {
+			return nil, err
+		}
+		response.EstimatedTotal = &total
+	}
 	return response, nil
 }
 
-// UpdateScanStatus 更新掃描任務狀態
-func (s *ScanService) UpdateScanStatus(id uint, status string) error {
+// encodeScanCursor 以掃描任務的建立時間與 ID 建立 cursor
+func encodeScanCursor(scan *model.ScanJob) string {
+	payload, _ := json.Marshal(repository.ScanCursor{CreatedAt: scan.CreatedAt, ID: scan.ID})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeScanCursor 解析掃描任務列表的 cursor
+func decodeScanCursor(cursor string) (*repository.ScanCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload repository.ScanCursor
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &payload, nil
+}
+
+// UpdateScanStatus 更新掃描任務的錯誤訊息，或將未結束的任務取消
+// running、completed、failed 只由 worker 設定；手動設定或不允許的轉換回傳 apperror.ErrConflict 類別的錯誤
+func (s *ScanService) UpdateScanStatus(id uint, req *dto.UpdateScanRequest) (*vo.ScanJobResponse, error) {
 	// 查詢掃描任務
 	scan, err := s.repo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("掃描任務不存在")
+			return nil, ErrScanNotFound
 		}
-		return err
+		return nil, err
+	}
+
+	fromStatus := scan.Status
+	// 非結束狀態重複設定為相同狀態視為無變更；結束狀態不可再次設定
+	if req.Status != nil && (*req.Status != fromStatus || scan.IsTerminal()) {
+		// running、completed、failed 只由 worker 設定：手動完成會略過結果寫入、問題解決與指標，
+		// 手動設為 running 的任務不會被任何 worker 執行
+		if !scan.IsTerminal() && *req.Status != model.ScanStatusCancelled {
+			return nil, newWorkerManagedError(scan.ID, fromStatus, *req.Status)
+		}
+		if err := transition(scan, *req.Status); err != nil {
+			return nil, err
+		}
+	}
+	if req.ErrorMessage != nil {
+		scan.ErrorMessage = *req.ErrorMessage
+	}
+
+	// 只在狀態未被其他請求或 worker 變更時寫入
+	updated, err := s.repo.UpdateStatus(scan, fromStatus)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, s.staleTransition(scan.ID, fromStatus, scan.Status)
+	}
+
+	if scan.Status == model.ScanStatusCancelled && fromStatus != model.ScanStatusCancelled && s.canceller != nil {
+		s.canceller.Cancel(scan.ID)
+	}
+
+	response := vo.FromScanJob(scan)
+	return &response, nil
+}
+
+// RetryScan 以失敗或已取消的掃描任務為基礎建立新的嘗試
+// 原任務保持不變，新任務以 retry_of_id 指向原任務並遞增 attempt
+func (s *ScanService) RetryScan(id uint) (*vo.ScanJobResponse, error) {
+	scan, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScanNotFound
+		}
+		return nil, err
 	}
 
-	// 更新狀態
+	if !scan.CanRetry() {
+		return nil, newTransitionError(scan.ID, scan.Status, model.ScanStatusPending)
+	}
+
+	attempt := scan.Attempt
+	if attempt < 1 {
+		attempt = 1
+	}
+	retry := &model.ScanJob{
+		Target:    scan.Target,
+		ScanType:  scan.ScanType,
+		Status:    model.ScanStatusPending,
+		Metadata:  scan.Metadata,
+		RetryOfID: &scan.ID,
+		Attempt:   attempt + 1,
+	}
+	if err := s.repo.Create(retry); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromScanJob(retry)
+	return &response, nil
+}
+
+// transition 依狀態機變更掃描任務狀態並更新時間欄位
+func transition(scan *model.ScanJob, status string) error {
+	if !scan.CanTransitionTo(status) {
+		return newTransitionError(scan.ID, scan.Status, status)
+	}
+
+	now := time.Now()
 	scan.Status = status
-	if status == "running" && scan.StartedAt == nil {
-		now := time.Now()
+	if status == model.ScanStatusRunning && scan.StartedAt == nil {
 		scan.StartedAt = &now
 	}
-	if status == "completed" || status == "failed" {
-		now := time.Now()
+	if scan.IsTerminal() {
 		scan.CompletedAt = &now
 	}
+	return nil
+}
+
+// staleTransition 條件更新失敗時，以資料庫中的最新狀態建立轉換錯誤
+func (s *ScanService) staleTransition(id uint, fromStatus, toStatus string) error {
+	if latest, err := s.repo.FindByID(id); err == nil {
+		fromStatus = latest.Status
+	}
+	return newTransitionError(id, fromStatus, toStatus)
+}
+
+// ImportResults 解析工具輸出並附加到既有的掃描任務
+// format 為空時使用掃描任務的 scan_type；只能匯入已結束的掃描任務，或未執行中的 custom 掃描任務
+func (s *ScanService) ImportResults(id uint, format string, r io.Reader) (*vo.ImportResultResponse, error) {
+	scan, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScanNotFound
+		}
+		return nil, err
+	}
+
+	// worker 結束掃描時會寫入結果，匯入到待執行或執行中的任務會重複寫入
+	if !canImportResults(scan) {
+		return nil, newScanNotFinishedError(scan.ID, scan.Status, "匯入結果")
+	}
+
+	if format == "" {
+		format = scan.Tool()
+	}
+	parse, err := parser.ForFormat(format)
+	if err != nil {
+		return nil, ErrInvalidResults.Wrap(err)
+	}
+
+	// 空的內容視為錯誤，避免回報成功卻沒有匯入任何結果
+	br := bufio.NewReader(r)
+	if _, err := br.Peek(1); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrInvalidResults.Wrap(errors.New("結果內容為空"))
+		}
+		return nil, resultsReadError(err)
+	}
+
+	result, err := parse(br)
+	if err != nil {
+		return nil, resultsReadError(err)
+	}
+
+	attachResults(scan, result, time.Now())
+	if err := s.carryForwardTriage(scan, result.Findings); err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.SaveFindings(scan.ID, scan.Status, result.Findings, s.linkResults(scan, result))
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		// 狀態在讀取後已被變更（例如 custom 任務被 worker 領取）
+		if latest, err := s.repo.FindByID(scan.ID); err == nil {
+			scan = latest
+		}
+		return nil, newScanNotFinishedError(scan.ID, scan.Status, "匯入結果")
+	}
+	s.notifyFindings(scan, result.Findings)
+
+	bySeverity := make(map[string]int)
+	for _, finding := range result.Findings {
+		bySeverity[finding.Severity]++
+	}
+
+	return &vo.ImportResultResponse{
+		ScanJobID:  scan.ID,
+		Format:     format,
+		Imported:   len(result.Findings),
+		Assets:     len(result.Assets),
+		BySeverity: bySeverity,
+	}, nil
+}
+
+// canImportResults 檢查掃描任務是否可以匯入結果
+func canImportResults(scan *model.ScanJob) bool {
+	if scan.ScanType == model.ScanTypeCustom {
+		return scan.Status != model.ScanStatusRunning
+	}
+	return scan.IsTerminal()
+}
+
+// resultsReadError 將讀取或解析掃描結果的錯誤轉換為領域錯誤
+func resultsReadError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return ErrResultsTooLarge.WithDetails(map[string]interface{}{"max_bytes": tooLarge.Limit})
+	}
+	return ErrInvalidResults.Wrap(err)
+}
+
+// ClaimPendingScans 領取待執行的掃描任務（供背景 worker 使用）
+func (s *ScanService) ClaimPendingScans(limit int) ([]model.ScanJob, error) {
+	return s.repo.ClaimPending(limit)
+}
+
+// CancelScan 取消待執行或執行中的掃描任務，並通知執行中的 worker 中止工具程序
+func (s *ScanService) CancelScan(id uint) (*vo.ScanJobResponse, error) {
+	scan, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScanNotFound
+		}
+		return nil, err
+	}
+
+	fromStatus := scan.Status
+	if err := transition(scan, model.ScanStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateStatus(scan, fromStatus)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		// 狀態在讀取後已被 worker 變更（例如剛好完成）
+		return nil, s.staleTransition(scan.ID, fromStatus, model.ScanStatusCancelled)
+	}
+
+	if s.canceller != nil {
+		s.canceller.Cancel(scan.ID)
+	}
+
+	response := vo.FromScanJob(scan)
+	return &response, nil
+}
+
+// CancelledScanIDs 從執行中的掃描任務中找出已被取消的 ID（供 worker 跨實例同步取消）
+func (s *ScanService) CancelledScanIDs(ids []uint) ([]uint, error) {
+	return s.repo.FindIDsByStatus(ids, model.ScanStatusCancelled)
+}
+
+// CompleteScan 將掃描任務標記為完成並寫入發現與資產
+// 若掃描任務在執行期間已被取消，只寫入結果而保留 cancelled 狀態
+func (s *ScanService) CompleteScan(scan *model.ScanJob, result *parser.Result) error {
+	now := time.Now()
+	scan.Status = model.ScanStatusCompleted
+	scan.CompletedAt = &now
+	scan.ErrorMessage = ""
+	if err := s.finishScan(scan, result, now); err != nil {
+		return err
+	}
+
+	// 完整完成的掃描代表目標的最新狀態，未再出現的問題視為已解決
+	if scan.Status != model.ScanStatusCompleted || s.issueRepo == nil {
+		return nil
+	}
+	_, err := s.issueRepo.ResolveMissing(scan.Target, scan.Tool(), scan.ID, now)
+	return err
+}
+
+// FailScan 將掃描任務標記為失敗，記錄錯誤訊息並保留已收集的部分結果
+func (s *ScanService) FailScan(scan *model.ScanJob, message string, result *parser.Result) error {
+	now := time.Now()
+	scan.Status = model.ScanStatusFailed
+	scan.CompletedAt = &now
+	scan.ErrorMessage = message
+	return s.finishScan(scan, result, now)
+}
+
+// FailStaleScans 將 started_at 早於 before 仍為 running 的掃描任務標記為失敗，回傳處理的數量
+// 執行的 worker 已中斷或寫回結果失敗時，任務不會再有其他元件結束它
+func (s *ScanService) FailStaleScans(before time.Time, limit int) (int, error) {
+	scans, err := s.repo.FindStaleRunning(before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	failed := 0
+	for i := range scans {
+		if err := s.FailScan(&scans[i], "worker 未在時限內回報結果（可能已中斷或寫入結果失敗）", nil); err != nil {
+			return failed, err
+		}
+		if scans[i].Status == model.ScanStatusFailed {
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+// RecordPartialResults 寫入已取消掃描任務在中止前收集到的部分結果
+func (s *ScanService) RecordPartialResults(scan *model.ScanJob, result *parser.Result) error {
+	if result == nil {
+		return nil
+	}
+	attachResults(scan, result, time.Now())
+	if err := s.carryForwardTriage(scan, result.Findings); err != nil {
+		return err
+	}
+	saved, err := s.repo.SaveFindings(scan.ID, model.ScanStatusCancelled, result.Findings, s.linkResults(scan, result))
+	if err != nil || !saved {
+		// 未寫入代表任務已被刪除
+		return err
+	}
+	s.notifyFindings(scan, result.Findings)
+	return nil
+}
+
+// finishScan 寫入結果並將執行中的掃描任務轉為最終狀態
+func (s *ScanService) finishScan(scan *model.ScanJob, result *parser.Result, now time.Time) error {
+	if result == nil {
+		result = &parser.Result{}
+	}
+	attachResults(scan, result, now)
+	if err := s.carryForwardTriage(scan, result.Findings); err != nil {
+		return err
+	}
+
+	// 問題、資產、發現與狀態在同一交易中寫入，失敗時不會留下已重新開啟卻沒有發現的問題
+	updated, err := s.repo.SaveResults(scan, model.ScanStatusRunning, result.Findings, s.linkResults(scan, result))
+	if err != nil {
+		return err
+	}
+	s.notifyFindings(scan, result.Findings)
+	if !updated {
+		// 執行期間狀態已被變更（例如已取消），以資料庫為準
+		if latest, err := s.repo.FindByID(scan.ID); err == nil {
+			*scan = *latest
+		}
+		return nil
+	}
+
+	for _, observer := range s.observers {
+		observer.ObserveScanFinished(scan)
+	}
+	return nil
+}
+
+// notifyFindings 通知觀察者已寫入的掃描發現
+func (s *ScanService) notifyFindings(scan *model.ScanJob, findings []model.ScanFinding) {
+	for _, observer := range s.observers {
+		observer.ObserveFindings(scan, findings)
+	}
+}
+
+// attachResults 將解析結果關聯到掃描任務並補上發現時間
+func attachResults(scan *model.ScanJob, result *parser.Result, now time.Time) {
+	for i := range result.Findings {
+		result.Findings[i].ScanJobID = scan.ID
+		if result.Findings[i].DiscoveredAt.IsZero() {
+			result.Findings[i].DiscoveredAt = now
+		}
+		result.Findings[i].Fingerprint = parser.Fingerprint(scan.Tool(), &result.Findings[i])
+	}
+	for i := range result.Assets {
+		result.Assets[i].ScanJobID = scan.ID
+		result.Assets[i].LastScanJobID = scan.ID
+		result.Assets[i].FirstSeen = now
+		result.Assets[i].LastSeen = now
+	}
+}
+
+// linkResults 回傳在寫入發現的交易中連結跨掃描問題並寫入資產的函式
+func (s *ScanService) linkResults(scan *model.ScanJob, result *parser.Result) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		if err := s.linkIssues(tx, scan, result.Findings); err != nil {
+			return err
+		}
+		return s.assetRepo.WithTx(tx).Upsert(result.Assets)
+	}
+}
+
+// carryForwardTriage 同一目標先前掃描中仍有效的抑制（誤報、未到期的風險接受）自動套用到相同指紋的發現
+// 以各指紋最近一次的分流結果為準，之後被重新開啟或標記為已修復的不會沿用
+func (s *ScanService) carryForwardTriage(scan *model.ScanJob, findings []model.ScanFinding) error {
+	if s.findingRepo == nil {
+		return nil
+	}
+
+	triaged, err := s.findingRepo.FindLatestTriaged(scan.Target, scan.ID, fingerprints(findings))
+	if err != nil {
+		return err
+	}
+	if len(triaged) == 0 {
+		return nil
+	}
 
-	// 儲存變更
-	return s.repo.Update(scan)
+	// 結果依分流時間由新到舊，只保留每個指紋最近一次的分流
+	latest := make(map[string]*model.ScanFinding, len(triaged))
+	for i := range triaged {
+		if _, ok := latest[triaged[i].Fingerprint]; !ok {
+			latest[triaged[i].Fingerprint] = &triaged[i]
+		}
+	}
+
+	now := time.Now()
+	for i := range findings {
+		prev, ok := latest[findings[i].Fingerprint]
+		if !ok || !prev.IsSuppressed(now) {
+			continue
+		}
+		findings[i].TriageStatus = prev.TriageStatus
+		findings[i].Assignee = prev.Assignee
+		findings[i].Justification = prev.Justification
+		findings[i].RiskAcceptedUntil = prev.RiskAcceptedUntil
+		findings[i].TriagedBy = prev.TriagedBy
+		findings[i].TriagedAt = prev.TriagedAt
+		findings[i].CarriedFromID = &prev.ID
+	}
+	return nil
 }
 
-// DeleteScan 刪除掃描任務
+// linkIssues 在寫入發現的交易中依指紋建立或更新問題，並將掃描發現連結到對應的問題
+// 同一掃描中相同指紋的發現視為一次出現，問題的嚴重程度取其中最高者
+func (s *ScanService) linkIssues(tx *gorm.DB, scan *model.ScanJob, findings []model.ScanFinding) error {
+	if s.issueRepo == nil || len(findings) == 0 {
+		return nil
+	}
+
+	issues := make([]model.Issue, 0, len(findings))
+	index := make(map[string]int, len(findings))
+	for i := range findings {
+		finding := &findings[i]
+		if j, ok := index[finding.Fingerprint]; ok {
+			issue := &issues[j]
+			if finding.SeverityScore() > model.SeverityScore(issue.Severity) {
+				issue.Severity = finding.Severity
+			}
+			if finding.DiscoveredAt.Before(issue.FirstSeen) {
+				issue.FirstSeen = finding.DiscoveredAt
+			}
+			if finding.DiscoveredAt.After(issue.LastSeen) {
+				issue.LastSeen = finding.DiscoveredAt
+			}
+			continue
+		}
+
+		index[finding.Fingerprint] = len(issues)
+		issues = append(issues, model.Issue{
+			Fingerprint:     finding.Fingerprint,
+			Tool:            scan.Tool(),
+			Target:          scan.Target,
+			Severity:        finding.Severity,
+			Title:           finding.Title,
+			Host:            finding.Host,
+			Port:            finding.Port,
+			Protocol:        finding.Protocol,
+			CVEID:           finding.CVEID,
+			Status:          model.IssueStatusOpen,
+			FirstSeen:       finding.DiscoveredAt,
+			LastSeen:        finding.DiscoveredAt,
+			OccurrenceCount: 1,
+			FirstScanJobID:  scan.ID,
+			LastScanJobID:   scan.ID,
+		})
+	}
+
+	if err := s.issueRepo.WithTx(tx).Upsert(issues); err != nil {
+		return err
+	}
+	for i := range findings {
+		id := issues[index[findings[i].Fingerprint]].ID
+		findings[i].IssueID = &id
+	}
+	return nil
+}
+
+// fingerprints 取得掃描發現的指紋（去除重複）
+func fingerprints(findings []model.ScanFinding) []string {
+	values := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		values = append(values, finding.Fingerprint)
+	}
+	return unique(values)
+}
+
+// DeleteScan 刪除已結束的掃描任務
 func (s *ScanService) DeleteScan(id uint) error {
 	// 檢查是否存在
-	_, err := s.repo.FindByID(id)
+	scan, err := s.repo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("掃描任務不存在")
+			return ErrScanNotFound
 		}
 		return err
 	}
 
-	// 執行刪除
-	return s.repo.Delete(id)
+	// 待執行或執行中的任務刪除後 worker 仍會執行並寫回結果，必須先取消
+	if !scan.IsTerminal() {
+		return newScanNotFinishedError(scan.ID, scan.Status, "刪除")
+	}
+
+	// 執行刪除；狀態在讀取後已被變更時重新檢查
+	deleted, err := s.repo.Delete(id, scan.Status)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		latest, err := s.repo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrScanNotFound
+			}
+			return err
+		}
+		return newScanNotFinishedError(latest.ID, latest.Status, "刪除")
+	}
+	return nil
 }
 
 // GetMetrics 取得掃描統計指標
@@ -151,6 +826,12 @@ func (s *ScanService) GetMetrics() (*vo.MetricsResponse, error) {
 		return nil, err
 	}
 
+	// 取得發現按嚴重程度統計
+	bySeverity, err := s.repo.CountAllFindingsBySeverity(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// 計算總數
 	var total int64
 	for _, count := range byStatus {
@@ -160,6 +841,10 @@ func (s *ScanService) GetMetrics() (*vo.MetricsResponse, error) {
 	response := &vo.MetricsResponse{
 		ScansTotal: total,
 		ByType:     byType,
+		BySeverity: bySeverity,
+
+		ByStatus:      byStatus,
+		FindingsTotal: sumCounts(bySeverity),
 	}
 
 	return response, nil
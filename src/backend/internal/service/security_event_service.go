@@ -0,0 +1,251 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrEventNotFound 安全事件不存在
+	ErrEventNotFound = apperror.NotFound("event_not_found", "安全事件不存在")
+	// ErrInvalidAssignee 指派對象不存在、已停用或沒有分流權限
+	ErrInvalidAssignee = apperror.Validation("invalid_assignee", "指派對象必須是具備分流權限的啟用中使用者")
+)
+
+// newEventTransitionError 建立不允許的安全事件狀態轉換錯誤
+func newEventTransitionError(id uint, from, to string) error {
+	return apperror.Conflict("invalid_transition", fmt.Sprintf("安全事件 %d 無法從 %s 轉換為 %s", id, from, to)).
+		WithDetails(map[string]interface{}{"event_id": id, "from": from, "to": to})
+}
+
+// SecurityEventService 安全事件業務邏輯層
+type SecurityEventService struct {
+	repo  *repository.SecurityEventRepository
+	users *repository.UserRepository
+}
+
+// NewSecurityEventService 建立新的 SecurityEventService
+func NewSecurityEventService(repo *repository.SecurityEventRepository, users *repository.UserRepository) *SecurityEventService {
+	return &SecurityEventService{repo: repo, users: users}
+}
+
+// CreateEvent 建立安全事件（狀態為 open）
+func (s *SecurityEventService) CreateEvent(req *dto.CreateSecurityEventRequest) (*vo.SecurityEventResponse, error) {
+	details := "{}"
+	if len(req.Details) > 0 {
+		data, err := json.Marshal(req.Details)
+		if err != nil {
+			return nil, err
+		}
+		details = string(data)
+	}
+
+	event := &model.SecurityEvent{
+		EventType:   req.EventType,
+		Severity:    req.Severity,
+		Source:      req.Source,
+		Destination: req.Destination,
+		Description: req.Description,
+		Details:     details,
+		Status:      model.EventStatusOpen,
+	}
+	if err := s.repo.Create(event); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromSecurityEvent(event)
+	return &response, nil
+}
+
+// GetEventByID 根據 ID 取得安全事件
+func (s *SecurityEventService) GetEventByID(id uint) (*vo.SecurityEventResponse, error) {
+	event, err := s.findEvent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := vo.FromSecurityEvent(event)
+	return &response, nil
+}
+
+// GetEvents 取得安全事件列表（分頁、過濾）
+func (s *SecurityEventService) GetEvents(params *dto.SecurityEventQueryParams) (*vo.PaginatedResponse, error) {
+	// 設定預設值
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.PageSize == 0 {
+		params.PageSize = 20
+	}
+
+	events, total, err := s.repo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	eventResponses := make([]vo.SecurityEventResponse, 0, len(events))
+	for i := range events {
+		eventResponses = append(eventResponses, vo.FromSecurityEvent(&events[i]))
+	}
+
+	// 計算總頁數
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize != 0 {
+		totalPages++
+	}
+
+	return &vo.PaginatedResponse{
+		Data:       eventResponses,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalCount: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// AssignEvent 將安全事件指派給具備分流權限的使用者（以使用者名稱或 Email 指定，空字串取消指派）
+func (s *SecurityEventService) AssignEvent(id uint, req *dto.AssignSecurityEventRequest) (*vo.SecurityEventResponse, error) {
+	event, err := s.findEvent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	assignee, err := resolveAssignee(s.users, req.AssignedTo)
+	if err != nil {
+		return nil, err
+	}
+
+	event.AssignedTo = assignee
+	if err := s.repo.UpdateAssignee(event); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromSecurityEvent(event)
+	return &response, nil
+}
+
+// UpdateEventStatus 依狀態機變更安全事件狀態
+// 結案（resolved、false_positive）時記錄解決時間，重新開啟時清除
+func (s *SecurityEventService) UpdateEventStatus(id uint, req *dto.UpdateSecurityEventStatusRequest) (*vo.SecurityEventResponse, error) {
+	event, err := s.findEvent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 重複設定為相同狀態視為無變更
+	if event.Status == req.Status {
+		response := vo.FromSecurityEvent(event)
+		return &response, nil
+	}
+	if !event.CanTransitionTo(req.Status) {
+		return nil, newEventTransitionError(event.ID, event.Status, req.Status)
+	}
+
+	fromStatus := event.Status
+	event.Status = req.Status
+	if event.IsClosed() {
+		now := time.Now()
+		event.ResolvedAt = &now
+	} else {
+		event.ResolvedAt = nil
+	}
+
+	// 只在狀態未被其他請求變更時寫入
+	updated, err := s.repo.UpdateStatus(event, fromStatus)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		if latest, err := s.repo.FindByID(event.ID); err == nil {
+			fromStatus = latest.Status
+		}
+		return nil, newEventTransitionError(event.ID, fromStatus, req.Status)
+	}
+
+	response := vo.FromSecurityEvent(event)
+	return &response, nil
+}
+
+// GetMetrics 取得安全事件統計，包含各嚴重程度的平均解決時間（僅計算 resolved 事件）
+func (s *SecurityEventService) GetMetrics(params *dto.SecurityEventMetricsParams) (*vo.SecurityEventMetricsResponse, error) {
+	byStatus, err := s.repo.CountBy("status", params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	bySeverity, err := s.repo.CountBy("severity", params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.ResolutionStats(params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// 計算總數
+	var total int64
+	for _, count := range byStatus {
+		total += count
+	}
+
+	resolution := make(map[string]vo.ResolutionStats, len(stats))
+	for _, stat := range stats {
+		mean := time.Duration(stat.MeanSeconds * float64(time.Second)).Round(time.Second)
+		resolution[stat.Severity] = vo.ResolutionStats{
+			Resolved:    stat.Count,
+			MeanSeconds: stat.MeanSeconds,
+			Mean:        mean.String(),
+		}
+	}
+
+	return &vo.SecurityEventMetricsResponse{
+		EventsTotal:        total,
+		ByStatus:           byStatus,
+		BySeverity:         bySeverity,
+		MeanResolutionTime: resolution,
+	}, nil
+}
+
+// resolveAssignee 以使用者名稱或 Email 找出具備分流權限的使用者，回傳其使用者名稱（空字串表示取消指派）
+func resolveAssignee(users *repository.UserRepository, login string) (string, error) {
+	login = strings.TrimSpace(login)
+	if login == "" {
+		return "", nil
+	}
+
+	user, err := users.FindByLogin(login)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrInvalidAssignee
+		}
+		return "", err
+	}
+	if !auth.Allowed(user, auth.PermissionTriage) {
+		return "", ErrInvalidAssignee
+	}
+	return user.Username, nil
+}
+
+// findEvent 查詢安全事件並轉換不存在錯誤
+func (s *SecurityEventService) findEvent(id uint) (*model.SecurityEvent, error) {
+	event, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/apperror"
+	"github.com/dennislwm/unified-security-platform/backend/internal/auth"
+	"github.com/dennislwm/unified-security-platform/backend/internal/dto"
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/repository"
+	"github.com/dennislwm/unified-security-platform/backend/internal/vo"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUserNotFound 使用者不存在
+	ErrUserNotFound = apperror.NotFound("user_not_found", "使用者不存在")
+	// ErrUsernameTaken 使用者名稱已被使用
+	ErrUsernameTaken = apperror.Conflict("username_taken", "使用者名稱已被使用")
+	// ErrEmailTaken Email 已被使用
+	ErrEmailTaken = apperror.Conflict("email_taken", "Email 已被使用")
+	// ErrLastAdmin 變更會使平台沒有任何啟用中的管理員
+	ErrLastAdmin = apperror.Conflict("last_admin", "至少需保留一位啟用中的管理員")
+	// ErrCannotDeleteSelf 管理員不可刪除自己的帳號
+	ErrCannotDeleteSelf = apperror.Conflict("cannot_delete_self", "不可刪除自己的帳號")
+	// ErrWrongPassword 目前密碼錯誤
+	ErrWrongPassword = apperror.Validation("wrong_password", "目前密碼錯誤")
+	// ErrWeakPassword 密碼不符合密碼政策
+	ErrWeakPassword = apperror.Validation("weak_password", "密碼不符合密碼政策")
+	// ErrUsersExist 資料庫已有使用者，不可再建立初始管理員
+	ErrUsersExist = apperror.Conflict("users_exist", "資料庫已有使用者，略過建立初始管理員")
+)
+
+// UserService 使用者管理業務邏輯層
+type UserService struct {
+	repo *repository.UserRepository
+}
+
+// NewUserService 建立新的 UserService
+func NewUserService(repo *repository.UserRepository) *UserService {
+	return &UserService{repo: repo}
+}
+
+// GetUsers 取得使用者列表（分頁、過濾）
+func (s *UserService) GetUsers(params *dto.UserQueryParams) (*vo.PaginatedResponse, error) {
+	// 設定預設值
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.PageSize == 0 {
+		params.PageSize = 20
+	}
+
+	users, total, err := s.repo.FindAll(params)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]vo.UserResponse, 0, len(users))
+	for i := range users {
+		userResponses = append(userResponses, vo.FromUser(&users[i]))
+	}
+
+	// 計算總頁數
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize != 0 {
+		totalPages++
+	}
+
+	return &vo.PaginatedResponse{
+		Data:       userResponses,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalCount: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetUserByID 根據 ID 取得使用者
+func (s *UserService) GetUserByID(id uint) (*vo.UserResponse, error) {
+	user, err := s.findUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := vo.FromUser(user)
+	return &response, nil
+}
+
+// CreateUser 建立使用者（未指定角色時為 user）
+func (s *UserService) CreateUser(req *dto.CreateUserRequest) (*vo.UserResponse, error) {
+	username := strings.TrimSpace(req.Username)
+	email := normalizeEmail(req.Email)
+
+	if err := s.ensureUnique(username, email, 0); err != nil {
+		return nil, err
+	}
+
+	hash, err := hashPassword(req.Password, username)
+	if err != nil {
+		return nil, err
+	}
+
+	role := req.Role
+	if role == "" {
+		role = "user"
+	}
+	user := &model.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: hash,
+		FullName:     req.FullName,
+		Role:         role,
+		IsActive:     true,
+	}
+	if err := s.repo.Create(user); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromUser(user)
+	return &response, nil
+}
+
+// UpdateUser 更新使用者資料、角色、啟用狀態或重設密碼
+func (s *UserService) UpdateUser(id uint, req *dto.UpdateUserRequest) (*vo.UserResponse, error) {
+	user, err := s.findUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 移除管理員角色或停用管理員前，確認仍有其他管理員
+	demoted := req.Role != nil && *req.Role != "admin"
+	deactivated := req.IsActive != nil && !*req.IsActive
+	if user.IsAdmin() && user.IsActive && (demoted || deactivated) {
+		if err := s.ensureOtherAdmin(); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Email != nil {
+		email := normalizeEmail(*req.Email)
+		if err := s.ensureUnique("", email, user.ID); err != nil {
+			return nil, err
+		}
+		user.Email = email
+	}
+	if req.FullName != nil {
+		user.FullName = *req.FullName
+	}
+	if req.Role != nil {
+		user.Role = *req.Role
+	}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+	if req.Password != nil {
+		if err := setPassword(user, *req.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromUser(user)
+	return &response, nil
+}
+
+// DeleteUser 軟刪除使用者（不可刪除自己或最後一位管理員）
+func (s *UserService) DeleteUser(id, actorID uint) error {
+	if id == actorID {
+		return ErrCannotDeleteSelf
+	}
+
+	user, err := s.findUser(id)
+	if err != nil {
+		return err
+	}
+	if user.IsAdmin() && user.IsActive {
+		if err := s.ensureOtherAdmin(); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.Delete(id)
+}
+
+// UpdateProfile 更新目前使用者的個人資料
+func (s *UserService) UpdateProfile(user *model.User, req *dto.UpdateProfileRequest) (*vo.UserResponse, error) {
+	if req.Email != nil {
+		email := normalizeEmail(*req.Email)
+		if err := s.ensureUnique("", email, user.ID); err != nil {
+			return nil, err
+		}
+		user.Email = email
+	}
+	if req.FullName != nil {
+		user.FullName = *req.FullName
+	}
+
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+
+	response := vo.FromUser(user)
+	return &response, nil
+}
+
+// ChangePassword 驗證目前密碼後變更密碼；變更前簽發的 token 隨即失效
+func (s *UserService) ChangePassword(user *model.User, req *dto.ChangePasswordRequest) error {
+	if !auth.CheckPassword(user.PasswordHash, req.CurrentPassword) {
+		return ErrWrongPassword
+	}
+	if err := setPassword(user, req.NewPassword); err != nil {
+		return err
+	}
+	return s.repo.Update(user)
+}
+
+// BootstrapAdmin 在沒有任何使用者的資料庫建立第一位管理員
+func (s *UserService) BootstrapAdmin(req *dto.CreateUserRequest) (*vo.UserResponse, error) {
+	count, err := s.repo.CountAll()
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrUsersExist
+	}
+
+	req.Role = "admin"
+	return s.CreateUser(req)
+}
+
+// findUser 查詢使用者並轉換不存在錯誤
+func (s *UserService) findUser(id uint) (*model.User, error) {
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// ensureUnique 檢查使用者名稱與 Email 未被其他使用者使用（空字串略過檢查）
+func (s *UserService) ensureUnique(username, email string, excludeID uint) error {
+	if username != "" {
+		taken, err := s.repo.UsernameTaken(username, excludeID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrUsernameTaken
+		}
+	}
+	if email != "" {
+		taken, err := s.repo.EmailTaken(email, excludeID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrEmailTaken
+		}
+	}
+	return nil
+}
+
+// ensureOtherAdmin 確認除了目標使用者外仍有啟用中的管理員
+func (s *UserService) ensureOtherAdmin() error {
+	count, err := s.repo.CountActiveAdmins()
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// setPassword 依密碼政策設定新密碼並記錄變更時間
+func setPassword(user *model.User, password string) error {
+	hash, err := hashPassword(password, user.Username)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.PasswordHash = hash
+	user.PasswordChangedAt = &now
+	return nil
+}
+
+// hashPassword 檢查密碼政策並雜湊密碼
+func hashPassword(password, username string) (string, error) {
+	if err := auth.ValidatePassword(password, username); err != nil {
+		return "", ErrWeakPassword.Wrap(err)
+	}
+	return auth.HashPassword(password)
+}
+
+// normalizeEmail 去除空白並轉為小寫
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
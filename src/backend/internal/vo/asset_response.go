@@ -0,0 +1,81 @@
+package vo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// AssetResponse 資產回應 VO
+type AssetResponse struct {
+	ID             uint      `json:"id"`
+	Hostname       string    `json:"hostname"`
+	Domain         string    `json:"domain,omitempty"`
+	IPAddresses    []string  `json:"ip_addresses"`
+	ASN            int       `json:"asn,omitempty"`
+	ASNDescription string    `json:"asn_description,omitempty"`
+	Source         string    `json:"source"`
+	Sources        []string  `json:"sources,omitempty"`
+	ScanJobID      uint      `json:"scan_job_id"`
+	LastScanJobID  uint      `json:"last_scan_job_id"`
+	FirstSeen      time.Time `json:"first_seen"`
+	LastSeen       time.Time `json:"last_seen"`
+}
+
+// FromAsset 從 Model 轉換為 VO
+func FromAsset(asset *model.Asset) AssetResponse {
+	return AssetResponse{
+		ID:             asset.ID,
+		Hostname:       asset.Hostname,
+		Domain:         asset.Domain,
+		IPAddresses:    parseTextArray(asset.IPAddresses),
+		ASN:            asset.ASN,
+		ASNDescription: asset.ASNDescription,
+		Source:         asset.Source,
+		Sources:        parseTextArray(asset.Sources),
+		ScanJobID:      asset.ScanJobID,
+		LastScanJobID:  asset.LastScanJobID,
+		FirstSeen:      asset.FirstSeen,
+		LastSeen:       asset.LastSeen,
+	}
+}
+
+// parseTextArray 將 PostgreSQL text[] 字面值（例如 {a,"b c"}）轉為字串切片
+func parseTextArray(literal string) []string {
+	values := make([]string, 0)
+	literal = strings.TrimSpace(literal)
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return values
+	}
+
+	var current strings.Builder
+	inQuotes, escaped, quoted := false, false, false
+	flush := func() {
+		if v := current.String(); v != "" || quoted {
+			values = append(values, v)
+		}
+		current.Reset()
+		quoted = false
+	}
+
+	for _, ch := range literal[1 : len(literal)-1] {
+		switch {
+		case escaped:
+			current.WriteRune(ch)
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case ch == ',' && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	flush()
+
+	return values
+}
@@ -0,0 +1,45 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// TokenResponse 登入 / 刷新 token 回應 VO
+type TokenResponse struct {
+	AccessToken      string       `json:"access_token"`
+	RefreshToken     string       `json:"refresh_token"`
+	TokenType        string       `json:"token_type"`
+	ExpiresIn        int64        `json:"expires_in"`
+	RefreshExpiresIn int64        `json:"refresh_expires_in"`
+	User             UserResponse `json:"user"`
+}
+
+// UserResponse 使用者回應 VO
+type UserResponse struct {
+	ID        uint       `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	FullName  string     `json:"full_name,omitempty"`
+	Role      string     `json:"role"`
+	IsActive  bool       `json:"is_active"`
+	LastLogin *time.Time `json:"last_login,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// FromUser 從 Model 轉換為 VO
+func FromUser(user *model.User) UserResponse {
+	return UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		FullName:  user.FullName,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+		LastLogin: user.LastLogin,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
@@ -1,5 +1,9 @@
 package vo
 
+import (
+	"time"
+)
+
 // ErrorResponse 錯誤回應
 type ErrorResponse struct {
 	Error   string                 `json:"error"`
@@ -21,6 +25,19 @@ type HealthResponse struct {
 	Service string `json:"service"`
 	Version string `json:"version"`
 	Time    string `json:"time"`
+
+	// 建置資訊與依賴檢查（readiness）
+	Commit    string             `json:"commit,omitempty"`
+	BuildTime string             `json:"build_time,omitempty"`
+	Checks    []DependencyStatus `json:"checks,omitempty"`
+}
+
+// DependencyStatus 單一依賴的檢查結果（未認證即可存取，不包含錯誤訊息、位址等內部資訊）
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Required  bool    `json:"required"`
+	LatencyMs float64 `json:"latency_ms"`
 }
 
 // MetricsResponse 監控指標回應
@@ -30,6 +47,34 @@ type MetricsResponse struct {
 	ThreatsBlocked int64            `json:"threats_blocked"`
 	ByType         map[string]int64 `json:"by_type,omitempty"`
 	BySeverity     map[string]int64 `json:"by_severity,omitempty"`
+
+	// 平台指標摘要（/metrics/summary）的細項與時間範圍
+	ByStatus      map[string]int64 `json:"by_status,omitempty"`
+	FindingsTotal int64            `json:"findings_total"`
+	ByTarget      []TargetMetrics  `json:"by_target,omitempty"`
+	From          *time.Time       `json:"from,omitempty"`
+	To            *time.Time       `json:"to,omitempty"`
+	GeneratedAt   *time.Time       `json:"generated_at,omitempty"`
+}
+
+// TargetMetrics 單一目標的掃描與發現統計
+type TargetMetrics struct {
+	Target     string     `json:"target"`
+	ScansTotal int64      `json:"scans_total"`
+	Findings   int64      `json:"findings"`
+	HighRisk   int64      `json:"high_risk"`
+	LastScanAt *time.Time `json:"last_scan_at,omitempty"`
+}
+
+// CursorResponse keyset 分頁回應；next_cursor 為空表示沒有下一頁
+type CursorResponse struct {
+	Data       interface{} `json:"data"`
+	Limit      int         `json:"limit"`
+	HasMore    bool        `json:"has_more"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+
+	// EstimatedTotal 符合條件的估計總數（僅在要求估計時回傳）
+	EstimatedTotal *int64 `json:"estimated_total,omitempty"`
 }
 
 
@@ -0,0 +1,7 @@
+package vo
+
+// BulkTriageResponse 批次分流回應
+type BulkTriageResponse struct {
+	Updated  int                   `json:"updated"`
+	Findings []ScanFindingResponse `json:"findings"`
+}
@@ -0,0 +1,51 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// IssueResponse 問題回應 VO
+type IssueResponse struct {
+	ID              uint       `json:"id"`
+	Fingerprint     string     `json:"fingerprint"`
+	Tool            string     `json:"tool"`
+	Target          string     `json:"target"`
+	Severity        string     `json:"severity"`
+	Title           string     `json:"title"`
+	Host            string     `json:"host,omitempty"`
+	Port            int        `json:"port,omitempty"`
+	Protocol        string     `json:"protocol,omitempty"`
+	CVEID           string     `json:"cve_id,omitempty"`
+	Status          string     `json:"status"`
+	FirstSeen       time.Time  `json:"first_seen"`
+	LastSeen        time.Time  `json:"last_seen"`
+	OccurrenceCount int        `json:"occurrence_count"`
+	FirstScanJobID  uint       `json:"first_scan_job_id"`
+	LastScanJobID   uint       `json:"last_scan_job_id"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+// FromIssue 從 Model 轉換為 VO
+func FromIssue(issue *model.Issue) IssueResponse {
+	return IssueResponse{
+		ID:              issue.ID,
+		Fingerprint:     issue.Fingerprint,
+		Tool:            issue.Tool,
+		Target:          issue.Target,
+		Severity:        issue.Severity,
+		Title:           issue.Title,
+		Host:            issue.Host,
+		Port:            issue.Port,
+		Protocol:        issue.Protocol,
+		CVEID:           issue.CVEID,
+		Status:          issue.Status,
+		FirstSeen:       issue.FirstSeen,
+		LastSeen:        issue.LastSeen,
+		OccurrenceCount: issue.OccurrenceCount,
+		FirstScanJobID:  issue.FirstScanJobID,
+		LastScanJobID:   issue.LastScanJobID,
+		ResolvedAt:      issue.ResolvedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package vo
+
+// ScanDiffResponse 兩次掃描的差異（以發現指紋比對）
+type ScanDiffResponse struct {
+	ScanID          uint                  `json:"scan_id"`
+	AgainstID       uint                  `json:"against_id"`
+	Target          string                `json:"target"`
+	ScanType        string                `json:"scan_type"`
+	Summary         ScanDiffSummary       `json:"summary"`
+	New             []ScanFindingResponse `json:"new"`
+	Resolved        []ScanFindingResponse `json:"resolved"`
+	Unchanged       []ScanFindingResponse `json:"unchanged"`
+	SeverityChanged []SeverityChange      `json:"severity_changed"`
+}
+
+// ScanDiffSummary 掃描差異各類別的數量
+type ScanDiffSummary struct {
+	New             int `json:"new"`
+	Resolved        int `json:"resolved"`
+	Unchanged       int `json:"unchanged"`
+	SeverityChanged int `json:"severity_changed"`
+}
+
+// SeverityChange 兩次掃描都出現但嚴重程度改變的發現
+type SeverityChange struct {
+	From    string              `json:"from"`
+	To      string              `json:"to"`
+	Finding ScanFindingResponse `json:"finding"`
+}
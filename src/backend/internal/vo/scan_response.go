@@ -17,6 +17,8 @@ type ScanJobResponse struct {
 	Duration     string     `json:"duration,omitempty"`
 	ErrorMessage string     `json:"error_message,omitempty"`
 	Metadata     string     `json:"metadata,omitempty"`
+	RetryOfID    *uint      `json:"retry_of_id,omitempty"`
+	Attempt      int        `json:"attempt"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 }
@@ -25,6 +27,32 @@ type ScanJobResponse struct {
 type ScanJobDetailResponse struct {
 	ScanJobResponse
 	Findings []ScanFindingResponse `json:"findings,omitempty"`
+
+	// 發現統計與分頁（summary 模式不含 findings 與 findings_page）
+	FindingsSummary *FindingsSummary `json:"findings_summary,omitempty"`
+	FindingsPage    *PageInfo        `json:"findings_page,omitempty"`
+}
+
+// FindingsSummary 掃描發現的嚴重程度統計（依嚴重程度由高到低）
+type FindingsSummary struct {
+	Total      int64           `json:"total"`
+	HighRisk   int64           `json:"high_risk"`
+	BySeverity []SeverityCount `json:"by_severity"`
+}
+
+// SeverityCount 單一嚴重程度的發現數量
+type SeverityCount struct {
+	Severity string `json:"severity"`
+	Score    int    `json:"score"`
+	Count    int64  `json:"count"`
+}
+
+// PageInfo 分頁資訊
+type PageInfo struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalCount int64 `json:"total_count"`
+	TotalPages int   `json:"total_pages"`
 }
 
 // ScanFindingResponse 掃描發現回應 VO
@@ -44,6 +72,29 @@ type ScanFindingResponse struct {
 	Remediation  string    `json:"remediation,omitempty"`
 	References   string    `json:"references,omitempty"`
 	DiscoveredAt time.Time `json:"discovered_at"`
+
+	// 分流
+	TriageStatus      string     `json:"triage_status"`
+	Suppressed        bool       `json:"suppressed"`
+	Assignee          string     `json:"assignee,omitempty"`
+	Justification     string     `json:"justification,omitempty"`
+	RiskAcceptedUntil *time.Time `json:"risk_accepted_until,omitempty"`
+	TriagedBy         string     `json:"triaged_by,omitempty"`
+	TriagedAt         *time.Time `json:"triaged_at,omitempty"`
+	CarriedFromID     *uint      `json:"carried_from_id,omitempty"`
+
+	// 跨掃描去重
+	Fingerprint string `json:"fingerprint,omitempty"`
+	IssueID     *uint  `json:"issue_id,omitempty"`
+}
+
+// ImportResultResponse 匯入掃描結果回應
+type ImportResultResponse struct {
+	ScanJobID  uint           `json:"scan_job_id"`
+	Format     string         `json:"format"`
+	Imported   int            `json:"imported"`
+	Assets     int            `json:"assets"`
+	BySeverity map[string]int `json:"by_severity"`
 }
 
 // PaginatedResponse 分頁回應
@@ -53,6 +104,9 @@ type PaginatedResponse struct {
 	PageSize   int         `json:"page_size"`
 	TotalCount int64       `json:"total_count"`
 	TotalPages int         `json:"total_pages"`
+
+	// TotalEstimated total_count 為查詢計畫的估計值而非精確計數
+	TotalEstimated bool `json:"total_estimated,omitempty"`
 }
 
 // FromScanJob 從 Model 轉換為 VO
@@ -66,6 +120,8 @@ func FromScanJob(job *model.ScanJob) ScanJobResponse {
 		CompletedAt:  job.CompletedAt,
 		ErrorMessage: job.ErrorMessage,
 		Metadata:     job.Metadata,
+		RetryOfID:    job.RetryOfID,
+		Attempt:      job.Attempt,
 		CreatedAt:    job.CreatedAt,
 		UpdatedAt:    job.UpdatedAt,
 	}
@@ -111,6 +167,18 @@ func FromScanFinding(finding *model.ScanFinding) ScanFindingResponse {
 		Remediation:  finding.Remediation,
 		References:   finding.References,
 		DiscoveredAt: finding.DiscoveredAt,
+
+		TriageStatus:      finding.TriageStatus,
+		Suppressed:        finding.IsSuppressed(time.Now()),
+		Assignee:          finding.Assignee,
+		Justification:     finding.Justification,
+		RiskAcceptedUntil: finding.RiskAcceptedUntil,
+		TriagedBy:         finding.TriagedBy,
+		TriagedAt:         finding.TriagedAt,
+		CarriedFromID:     finding.CarriedFromID,
+
+		Fingerprint: finding.Fingerprint,
+		IssueID:     finding.IssueID,
 	}
 }
 
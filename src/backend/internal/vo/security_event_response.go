@@ -0,0 +1,64 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+)
+
+// SecurityEventResponse 安全事件回應 VO
+type SecurityEventResponse struct {
+	ID             uint       `json:"id"`
+	EventType      string     `json:"event_type"`
+	Severity       string     `json:"severity"`
+	Source         string     `json:"source,omitempty"`
+	Destination    string     `json:"destination,omitempty"`
+	Description    string     `json:"description"`
+	Details        string     `json:"details,omitempty"`
+	Status         string     `json:"status"`
+	AssignedTo     string     `json:"assigned_to,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ResolutionTime string     `json:"resolution_time,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ResolutionStats 單一嚴重程度的解決時間統計
+type ResolutionStats struct {
+	Resolved    int64   `json:"resolved"`
+	MeanSeconds float64 `json:"mean_seconds"`
+	Mean        string  `json:"mean"`
+}
+
+// SecurityEventMetricsResponse 安全事件統計回應
+type SecurityEventMetricsResponse struct {
+	EventsTotal        int64                      `json:"events_total"`
+	ByStatus           map[string]int64           `json:"by_status"`
+	BySeverity         map[string]int64           `json:"by_severity"`
+	MeanResolutionTime map[string]ResolutionStats `json:"mean_resolution_time"`
+}
+
+// FromSecurityEvent 從 Model 轉換為 VO
+func FromSecurityEvent(event *model.SecurityEvent) SecurityEventResponse {
+	response := SecurityEventResponse{
+		ID:          event.ID,
+		EventType:   event.EventType,
+		Severity:    event.Severity,
+		Source:      event.Source,
+		Destination: event.Destination,
+		Description: event.Description,
+		Details:     event.Details,
+		Status:      event.Status,
+		AssignedTo:  event.AssignedTo,
+		ResolvedAt:  event.ResolvedAt,
+		CreatedAt:   event.CreatedAt,
+		UpdatedAt:   event.UpdatedAt,
+	}
+
+	// 計算解決時間
+	if event.ResolvedAt != nil {
+		response.ResolutionTime = event.ResolutionTime().String()
+	}
+
+	return response
+}
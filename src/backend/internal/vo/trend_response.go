@@ -0,0 +1,30 @@
+package vo
+
+import (
+	"time"
+)
+
+// TrendResponse 趨勢回應；buckets 依時間排序且不會缺漏區間（無資料時為 0）
+type TrendResponse struct {
+	Interval string        `json:"interval"`
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Target   string        `json:"target,omitempty"`
+	ScanType string        `json:"scan_type,omitempty"`
+	Buckets  []TrendBucket `json:"buckets"`
+}
+
+// TrendBucket 單一時間區間 [start, end) 的統計
+type TrendBucket struct {
+	Start              time.Time        `json:"start"`
+	End                time.Time        `json:"end"`
+	ScansStarted       int64            `json:"scans_started"`
+	ScansCompleted     int64            `json:"scans_completed"`
+	ScansFailed        int64            `json:"scans_failed"`
+	FindingsDiscovered map[string]int64 `json:"findings_discovered"`
+	OpenHighRisk       map[string]int64 `json:"open_high_risk"`
+	IssuesResolved     int64            `json:"issues_resolved"`
+	MTTRHours          *float64         `json:"mttr_hours,omitempty"`
+	EventsOpened       int64            `json:"events_opened"`
+	EventsResolved     int64            `json:"events_resolved"`
+}
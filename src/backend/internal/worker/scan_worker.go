@@ -0,0 +1,258 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+	"github.com/dennislwm/unified-security-platform/backend/internal/scanner"
+	"github.com/dennislwm/unified-security-platform/backend/internal/service"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
+)
+
+const (
+	// reapInterval 檢查中斷任務的間隔
+	reapInterval = time.Minute
+	// reapBatchSize 每次標記為失敗的中斷任務上限
+	reapBatchSize = 100
+)
+
+// ScanStore worker 領取任務與寫回結果所需的操作（由 *service.ScanService 實作）
+type ScanStore interface {
+	ClaimPendingScans(limit int) ([]model.ScanJob, error)
+	CancelledScanIDs(ids []uint) ([]uint, error)
+	CompleteScan(scan *model.ScanJob, result *parser.Result) error
+	FailScan(scan *model.ScanJob, message string, result *parser.Result) error
+	RecordPartialResults(scan *model.ScanJob, result *parser.Result) error
+	FailStaleScans(before time.Time, limit int) (int, error)
+}
+
+// ScanWorker 背景掃描執行器：輪詢待執行的掃描任務並交由對應的 Scanner 執行
+type ScanWorker struct {
+	service      ScanStore
+	registry     *scanner.Registry
+	logger       *logger.Logger
+	concurrency  int
+	pollInterval time.Duration
+	timeout      time.Duration
+	staleGrace   time.Duration
+
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running map[uint]context.CancelCauseFunc
+}
+
+// NewScanWorker 建立新的 ScanWorker
+// running 超過 timeout 加上 staleGrace 的任務視為執行的 worker 已中斷（所有實例應使用相同的 timeout）
+func NewScanWorker(
+	service ScanStore,
+	registry *scanner.Registry,
+	logger *logger.Logger,
+	concurrency int,
+	pollInterval time.Duration,
+	timeout time.Duration,
+	staleGrace time.Duration,
+) *ScanWorker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &ScanWorker{
+		service:      service,
+		registry:     registry,
+		logger:       logger.With("component", "scan_worker"),
+		concurrency:  concurrency,
+		pollInterval: pollInterval,
+		timeout:      timeout,
+		staleGrace:   staleGrace,
+		running:      make(map[uint]context.CancelCauseFunc),
+	}
+}
+
+// Cancel 中止本實例正在執行的掃描任務，回傳該任務是否在本實例執行中
+func (w *ScanWorker) Cancel(id uint) bool {
+	w.mu.Lock()
+	cancel, ok := w.running[id]
+	w.mu.Unlock()
+
+	if ok {
+		cancel(service.ErrScanCancelled)
+	}
+	return ok
+}
+
+// Run 持續輪詢並執行掃描任務，直到 ctx 取消且所有執行中的任務結束
+func (w *ScanWorker) Run(ctx context.Context) {
+	slots := make(chan struct{}, w.concurrency)
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	w.logger.Info("🔄 掃描 worker 已啟動", "concurrency", w.concurrency, "scan_types", w.registry.ScanTypes())
+
+	var lastReap time.Time
+	for {
+		if time.Since(lastReap) >= reapInterval {
+			w.reapStale()
+			lastReap = time.Now()
+		}
+		w.syncCancellations()
+		w.dispatch(ctx, slots)
+
+		select {
+		case <-ctx.Done():
+			w.wg.Wait()
+			w.logger.Info("🛑 掃描 worker 已停止")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reapStale 將執行時間超過上限仍未結束的任務標記為失敗
+// 執行的實例中斷，或寫回結果與狀態都失敗時，任務會停留在 running
+func (w *ScanWorker) reapStale() {
+	failed, err := w.service.FailStaleScans(time.Now().Add(-(w.timeout + w.staleGrace)), reapBatchSize)
+	if err != nil {
+		w.logger.Error("❌ 處理中斷的掃描任務失敗", "error", err)
+		return
+	}
+	if failed > 0 {
+		w.logger.Warn("⚠️  已將中斷的掃描任務標記為失敗", "count", failed)
+	}
+}
+
+// syncCancellations 中止已在資料庫中被取消的執行中任務（取消請求可能由其他實例處理）
+func (w *ScanWorker) syncCancellations() {
+	w.mu.Lock()
+	ids := make([]uint, 0, len(w.running))
+	for id := range w.running {
+		ids = append(ids, id)
+	}
+	w.mu.Unlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	cancelled, err := w.service.CancelledScanIDs(ids)
+	if err != nil {
+		w.logger.Error("❌ 查詢已取消的掃描任務失敗", "error", err)
+		return
+	}
+	for _, id := range cancelled {
+		w.Cancel(id)
+	}
+}
+
+// dispatch 依可用名額領取待執行任務並啟動執行
+func (w *ScanWorker) dispatch(ctx context.Context, slots chan struct{}) {
+	free := cap(slots) - len(slots)
+	if free == 0 || ctx.Err() != nil {
+		return
+	}
+
+	jobs, err := w.service.ClaimPendingScans(free)
+	if err != nil {
+		w.logger.Error("❌ 領取掃描任務失敗", "error", err)
+		return
+	}
+
+	for i := range jobs {
+		job := jobs[i]
+		slots <- struct{}{}
+		w.wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				w.wg.Done()
+			}()
+			w.execute(ctx, &job)
+		}()
+	}
+}
+
+// execute 執行單一掃描任務並寫回結果
+func (w *ScanWorker) execute(ctx context.Context, job *model.ScanJob) {
+	log := w.logger.With("scan_id", job.ID, "scan_type", job.ScanType, "target", job.Target)
+	log.Info("▶️  開始執行掃描")
+
+	s, err := w.registry.Get(job.ScanType)
+	if err != nil {
+		w.fail(log, job, err, nil)
+		return
+	}
+
+	cancelCtx, cancelScan := context.WithCancelCause(ctx)
+	defer cancelScan(nil)
+	scanCtx, cancel := context.WithTimeout(cancelCtx, w.timeout)
+	defer cancel()
+
+	w.track(job.ID, cancelScan)
+	defer w.untrack(job.ID)
+
+	result, err := s.Scan(scanCtx, job)
+	switch {
+	case err == nil:
+		if err := w.service.CompleteScan(job, result); err != nil {
+			// 結果未寫入時將任務標記為失敗，避免停留在 running
+			log.Error("❌ 寫入掃描結果失敗", "error", err)
+			w.fail(log, job, fmt.Errorf("寫入掃描結果失敗: %w", err), nil)
+			return
+		}
+		log.Info("✅ 掃描完成", "status", job.Status,
+			"findings", len(result.Findings), "assets", len(result.Assets), "duration", job.Duration().String())
+	case errors.Is(err, service.ErrScanCancelled):
+		if err := w.service.RecordPartialResults(job, result); err != nil {
+			log.Error("❌ 寫入部分掃描結果失敗", "error", err)
+			return
+		}
+		log.Info("⏹️  掃描已取消", "findings", countFindings(result), "assets", countAssets(result))
+	case errors.Is(err, context.DeadlineExceeded):
+		w.fail(log, job, fmt.Errorf("掃描逾時（超過 %s）", w.timeout), result)
+	case errors.Is(err, context.Canceled):
+		w.fail(log, job, errors.New("服務關閉，掃描已中止"), result)
+	default:
+		w.fail(log, job, err, result)
+	}
+}
+
+// track 記錄執行中任務的取消函式
+func (w *ScanWorker) track(id uint, cancel context.CancelCauseFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.running[id] = cancel
+}
+
+// untrack 移除已結束任務的取消函式
+func (w *ScanWorker) untrack(id uint) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.running, id)
+}
+
+// fail 將掃描任務標記為失敗，並保留已收集的部分結果
+func (w *ScanWorker) fail(log *logger.Logger, job *model.ScanJob, cause error, result *parser.Result) {
+	log.Warn("⚠️  掃描失敗", "error", cause, "findings", countFindings(result))
+	if err := w.service.FailScan(job, cause.Error(), result); err != nil {
+		log.Error("❌ 更新掃描狀態失敗", "error", err)
+	}
+}
+
+// countFindings 取得結果中的發現數量（結果可能為 nil）
+func countFindings(result *parser.Result) int {
+	if result == nil {
+		return 0
+	}
+	return len(result.Findings)
+}
+
+// countAssets 取得結果中的資產數量（結果可能為 nil）
+func countAssets(result *parser.Result) int {
+	if result == nil {
+		return 0
+	}
+	return len(result.Assets)
+}
@@ -0,0 +1,271 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/internal/model"
+	"github.com/dennislwm/unified-security-platform/backend/internal/parser"
+	"github.com/dennislwm/unified-security-platform/backend/internal/scanner"
+	"github.com/dennislwm/unified-security-platform/backend/pkg/logger"
+)
+
+// outcome worker 寫回的掃描結果
+type outcome struct {
+	status  string
+	message string
+	result  *parser.Result
+}
+
+// fakeStore 以記憶體實作 ScanStore，將寫回的結果送到 done
+type fakeStore struct {
+	mu        sync.Mutex
+	pending   []model.ScanJob
+	cancelled map[uint]bool
+	done      chan outcome
+
+	completeErr error       // CompleteScan 回傳的錯誤（模擬寫入結果失敗）
+	staleCuts   []time.Time // FailStaleScans 收到的時間界線
+}
+
+func newFakeStore(jobs ...model.ScanJob) *fakeStore {
+	return &fakeStore{pending: jobs, cancelled: make(map[uint]bool), done: make(chan outcome, len(jobs))}
+}
+
+func (f *fakeStore) ClaimPendingScans(limit int) ([]model.ScanJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if limit > len(f.pending) {
+		limit = len(f.pending)
+	}
+	jobs := f.pending[:limit]
+	f.pending = f.pending[limit:]
+	for i := range jobs {
+		jobs[i].Status = model.ScanStatusRunning
+	}
+	return jobs, nil
+}
+
+func (f *fakeStore) CancelledScanIDs(ids []uint) ([]uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var cancelled []uint
+	for _, id := range ids {
+		if f.cancelled[id] {
+			cancelled = append(cancelled, id)
+		}
+	}
+	return cancelled, nil
+}
+
+func (f *fakeStore) CompleteScan(scan *model.ScanJob, result *parser.Result) error {
+	if f.completeErr != nil {
+		return f.completeErr
+	}
+	f.done <- outcome{status: model.ScanStatusCompleted, result: result}
+	return nil
+}
+
+func (f *fakeStore) FailScan(scan *model.ScanJob, message string, result *parser.Result) error {
+	f.done <- outcome{status: model.ScanStatusFailed, message: message, result: result}
+	return nil
+}
+
+func (f *fakeStore) RecordPartialResults(scan *model.ScanJob, result *parser.Result) error {
+	f.done <- outcome{status: model.ScanStatusCancelled, result: result}
+	return nil
+}
+
+func (f *fakeStore) FailStaleScans(before time.Time, limit int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.staleCuts = append(f.staleCuts, before)
+	return 0, nil
+}
+
+// cancel 模擬其他實例在資料庫中取消任務
+func (f *fakeStore) cancel(id uint) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled[id] = true
+}
+
+// startWorker 以 fake-scanner.sh 作為 nuclei 掃描器啟動 worker，測試結束時停止
+func startWorker(t *testing.T, store *fakeStore, mode string, timeout time.Duration) (w *ScanWorker, ready string) {
+	t.Helper()
+
+	ready = filepath.Join(t.TempDir(), "ready")
+	args := strings.Join([]string{"../scanner/testdata/fake-scanner.sh", mode, scanner.TargetPlaceholder, ready}, " ")
+	registry := scanner.NewRegistry(scanner.NewCommandScanner("nuclei", "sh", args, parser.Findings(parser.ParseNuclei)))
+
+	w = NewScanWorker(store, registry, logger.NewLogger("test"), 2, 20*time.Millisecond, timeout, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(stopped)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return w, ready
+}
+
+// waitOutcome 等待 worker 寫回結果
+func waitOutcome(t *testing.T, store *fakeStore) outcome {
+	t.Helper()
+
+	select {
+	case o := <-store.done:
+		return o
+	case <-time.After(15 * time.Second):
+		t.Fatal("worker did not finish the scan")
+		return outcome{}
+	}
+}
+
+// waitForFile 等待 hang 模式的掃描工具開始等待
+func waitForFile(t *testing.T, path string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was not created", path)
+}
+
+// nucleiJob 建立 nuclei 掃描任務
+func nucleiJob(id uint) model.ScanJob {
+	return model.ScanJob{ID: id, Target: "https://example.com", ScanType: "nuclei", Status: model.ScanStatusPending}
+}
+
+func TestScanWorkerCompletesScan(t *testing.T) {
+	store := newFakeStore(nucleiJob(1))
+	startWorker(t, store, "complete", time.Minute)
+
+	o := waitOutcome(t, store)
+	if o.status != model.ScanStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", o.status, o.message)
+	}
+	if len(o.result.Findings) != 2 {
+		t.Errorf("findings = %d, want 2", len(o.result.Findings))
+	}
+}
+
+func TestScanWorkerFailsScan(t *testing.T) {
+	store := newFakeStore(nucleiJob(1))
+	startWorker(t, store, "fail", time.Minute)
+
+	o := waitOutcome(t, store)
+	if o.status != model.ScanStatusFailed {
+		t.Fatalf("status = %s, want failed", o.status)
+	}
+	if !strings.Contains(o.message, "exit status 2") || !strings.Contains(o.message, "Could not load templates") {
+		t.Errorf("message = %q, want exit status and stderr tail", o.message)
+	}
+}
+
+func TestScanWorkerFailsUnsupportedScanType(t *testing.T) {
+	store := newFakeStore(model.ScanJob{ID: 1, Target: "example.com", ScanType: "amass"})
+	startWorker(t, store, "complete", time.Minute)
+
+	if o := waitOutcome(t, store); o.status != model.ScanStatusFailed || !strings.Contains(o.message, "amass") {
+		t.Errorf("outcome = %+v, want failed for unsupported scan type", o)
+	}
+}
+
+func TestScanWorkerCancelKeepsPartialResults(t *testing.T) {
+	store := newFakeStore(nucleiJob(1))
+	w, ready := startWorker(t, store, "hang", time.Minute)
+
+	waitForFile(t, ready)
+	if !w.Cancel(1) {
+		t.Fatal("Cancel should find the running scan")
+	}
+
+	o := waitOutcome(t, store)
+	if o.status != model.ScanStatusCancelled {
+		t.Fatalf("status = %s (%s), want cancelled", o.status, o.message)
+	}
+	if len(o.result.Findings) != 1 {
+		t.Errorf("findings = %d, want 1 partial finding", len(o.result.Findings))
+	}
+	if w.Cancel(1) {
+		t.Error("Cancel should not find a finished scan")
+	}
+}
+
+func TestScanWorkerSyncsCancellationsFromStore(t *testing.T) {
+	store := newFakeStore(nucleiJob(1))
+	_, ready := startWorker(t, store, "hang", time.Minute)
+
+	waitForFile(t, ready)
+	store.cancel(1)
+
+	if o := waitOutcome(t, store); o.status != model.ScanStatusCancelled {
+		t.Fatalf("status = %s (%s), want cancelled", o.status, o.message)
+	}
+}
+
+func TestScanWorkerTimeout(t *testing.T) {
+	store := newFakeStore(nucleiJob(1))
+	startWorker(t, store, "hang", time.Second)
+
+	o := waitOutcome(t, store)
+	if o.status != model.ScanStatusFailed || !strings.Contains(o.message, "掃描逾時") {
+		t.Fatalf("outcome = %s %q, want failed with timeout", o.status, o.message)
+	}
+	if len(o.result.Findings) != 1 {
+		t.Errorf("findings = %d, want 1 partial finding", len(o.result.Findings))
+	}
+}
+
+func TestScanWorkerFailsScanWhenResultsCannotBeSaved(t *testing.T) {
+	store := newFakeStore(nucleiJob(1))
+	store.completeErr = errors.New("connection reset")
+	startWorker(t, store, "complete", time.Minute)
+
+	o := waitOutcome(t, store)
+	if o.status != model.ScanStatusFailed || !strings.Contains(o.message, "connection reset") {
+		t.Fatalf("outcome = %s %q, want failed with the write error", o.status, o.message)
+	}
+}
+
+func TestScanWorkerReapsStaleScansOnStart(t *testing.T) {
+	store := newFakeStore()
+	start := time.Now()
+	startWorker(t, store, "complete", time.Hour)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		store.mu.Lock()
+		cuts := append([]time.Time(nil), store.staleCuts...)
+		store.mu.Unlock()
+
+		if len(cuts) > 0 {
+			// 界線為 timeout（1h）加上 staleGrace（1m）之前
+			want := start.Add(-time.Hour - time.Minute)
+			if d := cuts[0].Sub(want); d < 0 || d > 5*time.Second {
+				t.Errorf("stale cutoff = %v, want about %v", cuts[0], want)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("worker did not reap stale scans")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
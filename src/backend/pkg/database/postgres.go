@@ -51,11 +51,6 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	return db, nil
 }
 
-// AutoMigrate 執行資料庫遷移
-func AutoMigrate(db *gorm.DB, models ...interface{}) error {
-	return db.AutoMigrate(models...)
-}
-
 // Close 關閉資料庫連接
 func Close(db *gorm.DB) error {
 	sqlDB, err := db.DB()
@@ -0,0 +1,82 @@
+package hexstrike
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker 斷路器：連續失敗達門檻後開路，冷卻時間過後放行一個試探請求（半開），成功才恢復
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newBreaker 建立斷路器；threshold 小於 1 時不啟用
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow 判斷是否允許送出請求
+func (b *breaker) allow() bool {
+	if b.threshold < 1 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	// 開路中：冷卻結束後只放行一個試探請求
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// success 記錄成功並關閉斷路器
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+// failure 記錄失敗；達門檻或試探失敗時（重新）開路
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= b.threshold || b.probing {
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
+
+// release 放棄本次請求的結果（例如呼叫端取消），釋放試探名額但不影響失敗計數
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// open 斷路器是否為開路狀態
+func (b *breaker) open() bool {
+	if b.threshold < 1 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold
+}
@@ -0,0 +1,252 @@
+package hexstrike
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/config"
+)
+
+const (
+	// requestTimeout 健康檢查等輕量請求的逾時時間
+	requestTimeout = 10 * time.Second
+	// retryBackoff 第一次重試前的等待時間，之後每次加倍
+	retryBackoff = 500 * time.Millisecond
+	// maxErrorBytes 錯誤訊息中保留的回應內容長度上限
+	maxErrorBytes = 2048
+)
+
+// ErrCircuitOpen 斷路器開啟時不送出請求
+var ErrCircuitOpen = errors.New("HexStrike AI 連續失敗，斷路器開啟中")
+
+// errInvalidResponse 服務有回應但內容無法解析
+var errInvalidResponse = errors.New("無法解析 HexStrike AI 回應")
+
+// toolNamePattern 工具名稱（對應 /api/tools/{tool}）
+var toolNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// APIError HexStrike AI 回應的非 2xx 錯誤
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error 實作 error
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HexStrike AI 回應 %d: %s", e.StatusCode, e.Message)
+}
+
+// ToolResult 工具執行結果（HexStrike AI 執行指令後的回應）
+type ToolResult struct {
+	Success        bool    `json:"success"`
+	Stdout         string  `json:"stdout"`
+	Stderr         string  `json:"stderr"`
+	ReturnCode     int     `json:"return_code"`
+	TimedOut       bool    `json:"timed_out"`
+	PartialResults bool    `json:"partial_results"`
+	ExecutionTime  float64 `json:"execution_time"`
+	Timestamp      string  `json:"timestamp"`
+}
+
+// HealthStatus HexStrike AI 健康檢查回應
+type HealthStatus struct {
+	Status              string          `json:"status"`
+	Version             string          `json:"version"`
+	Uptime              float64         `json:"uptime"`
+	TotalToolsAvailable int             `json:"total_tools_available"`
+	ToolsStatus         map[string]bool `json:"tools_status"`
+}
+
+// Client HexStrike AI Flask API 客戶端：逾時、失敗重試與斷路器
+type Client struct {
+	baseURL     string
+	http        *http.Client
+	toolTimeout time.Duration
+	retries     int
+	backoff     time.Duration
+	breaker     *breaker
+}
+
+// NewClient 建立新的 HexStrike AI 客戶端
+func NewClient(cfg *config.ServicesConfig) *Client {
+	return &Client{
+		baseURL:     strings.TrimRight(cfg.HexStrikeURL, "/"),
+		http:        &http.Client{},
+		toolTimeout: cfg.HexStrikeTimeout,
+		retries:     cfg.HexStrikeRetries,
+		backoff:     retryBackoff,
+		breaker:     newBreaker(cfg.HexStrikeBreakerFailures, cfg.HexStrikeBreakerCooldown),
+	}
+}
+
+// ValidToolName 檢查工具名稱格式（小寫英數、底線與連字號）
+func ValidToolName(tool string) bool {
+	return toolNamePattern.MatchString(tool)
+}
+
+// CircuitOpen 斷路器是否開啟中
+func (c *Client) CircuitOpen() bool {
+	return c.breaker.open()
+}
+
+// Health 查詢 HexStrike AI 健康狀態與可用工具
+func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
+	var status HealthStatus
+	if err := c.do(ctx, requestTimeout, http.MethodGet, "/health", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RunTool 以 POST /api/tools/{tool} 執行工具並等待結果
+//
+// HexStrike AI 會在工具結束後才回應，因此單次呼叫以 HexStrikeTimeout 為上限；
+// ctx 被取消時立即返回，但已在 HexStrike AI 端啟動的程序不會被中止。
+func (c *Client) RunTool(ctx context.Context, tool string, params map[string]interface{}) (*ToolResult, error) {
+	if !ValidToolName(tool) {
+		return nil, fmt.Errorf("無效的 HexStrike 工具名稱: %q", tool)
+	}
+
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("無法編碼工具參數: %w", err)
+	}
+
+	var result ToolResult
+	if err := c.do(ctx, c.toolTimeout, http.MethodPost, "/api/tools/"+tool, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do 在 timeout 內送出請求並解碼回應（timeout 為 0 時不限制）；可重試的錯誤（見 retryable）以指數退避重試
+// 4xx 表示服務正常運作，不計入斷路器的失敗次數；客戶端自身的逾時表示服務沒有回應，計入失敗，
+// 只有呼叫端的 parent 被取消或逾時時才放棄本次結果
+func (c *Client) do(parent context.Context, timeout time.Duration, method, path string, body []byte, out interface{}) error {
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if !c.breaker.allow() {
+			return ErrCircuitOpen
+		}
+
+		err := c.send(ctx, method, path, body, out)
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			c.breaker.success()
+			return nil
+		case parent.Err() != nil:
+			c.breaker.release()
+			return err
+		case ctx.Err() != nil:
+			c.breaker.failure()
+			return err
+		case errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError && apiErr.StatusCode != http.StatusTooManyRequests:
+			c.breaker.success()
+			return err
+		default:
+			c.breaker.failure()
+		}
+
+		if !retryable(method, err) || attempt >= c.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.backoff << attempt):
+		}
+	}
+}
+
+// send 送出單次請求
+func (c *Client) send(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidResponse, err)
+	}
+	return nil
+}
+
+// newAPIError 從錯誤回應取得訊息（優先使用 JSON 的 error 欄位）
+func newAPIError(resp *http.Response) *APIError {
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBytes))
+
+	var payload struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(content))
+	if json.Unmarshal(content, &payload) == nil && payload.Error != "" {
+		message = payload.Error
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: message}
+}
+
+// retryable 判斷錯誤是否值得重試
+//
+// POST /api/tools/{tool} 不是冪等的，每次送出都會在 HexStrike AI 啟動一次工具；
+// 504 等錯誤可能來自前方的 proxy，工具其實已在執行，因此只在連線建立前失敗（dial）時重試。
+// GET 另外重試其他連線錯誤與暫時性的服務錯誤（429、502、503、504）。
+func retryable(method string, err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if method != http.MethodGet {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return !errors.Is(err, errInvalidResponse)
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,377 @@
+package hexstrike
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dennislwm/unified-security-platform/backend/config"
+)
+
+// newTestClient 建立指向測試伺服器的客戶端（重試等待縮短為 1ms）
+func newTestClient(t *testing.T, handler http.HandlerFunc, cfg config.ServicesConfig) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg.HexStrikeURL = srv.URL
+	c := NewClient(&cfg)
+	c.backoff = time.Millisecond
+	return c
+}
+
+// block 阻塞到請求被取消或測試結束（用戶端取消時伺服器端不一定會察覺）
+func block(r *http.Request, done <-chan struct{}) {
+	select {
+	case <-r.Context().Done():
+	case <-done:
+	}
+}
+
+// statusSequence 依序回應指定狀態碼，最後一個狀態碼之後都回傳成功的工具結果
+func statusSequence(calls *int32, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			w.Write([]byte(`{"error":"temporary"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"stdout":"ok","return_code":0}`))
+	}
+}
+
+// transportFunc 以函式實作 http.RoundTripper
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// failFirst 前 n 次請求回傳 err，之後交給預設的 transport
+func failFirst(c *Client, calls *int32, n int32, err error) {
+	c.http.Transport = transportFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(calls, 1) <= n {
+			return nil, err
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+}
+
+func TestHealthRetriesTransientErrors(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, statusSequence(&calls, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests),
+		config.ServicesConfig{HexStrikeRetries: 3, HexStrikeBreakerFailures: 5, HexStrikeBreakerCooldown: time.Minute})
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 4 {
+		t.Errorf("calls = %d, want 4", n)
+	}
+	if c.CircuitOpen() {
+		t.Error("breaker should be closed after a success")
+	}
+}
+
+func TestRunToolDoesNotRetryServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		var calls int32
+		c := newTestClient(t, statusSequence(&calls, status),
+			config.ServicesConfig{HexStrikeRetries: 3, HexStrikeBreakerFailures: 5, HexStrikeBreakerCooldown: time.Minute})
+
+		// 工具可能已在 HexStrike AI 端啟動，重送會再執行一次
+		var apiErr *APIError
+		if _, err := c.RunTool(context.Background(), "nmap", nil); !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+			t.Errorf("%d: err = %v, want APIError", status, err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("%d: calls = %d, want 1", status, n)
+		}
+	}
+}
+
+func TestRunToolRetriesDialErrors(t *testing.T) {
+	var served, calls int32
+	c := newTestClient(t, statusSequence(&served),
+		config.ServicesConfig{HexStrikeRetries: 3, HexStrikeBreakerFailures: 5, HexStrikeBreakerCooldown: time.Minute})
+	failFirst(c, &calls, 2, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+	result, err := c.RunTool(context.Background(), "nmap", nil)
+	if err != nil {
+		t.Fatalf("RunTool: %v", err)
+	}
+	if !result.Success || result.Stdout != "ok" {
+		t.Errorf("result = %+v", result)
+	}
+	if n := atomic.LoadInt32(&served); n != 1 {
+		t.Errorf("served = %d, want 1", n)
+	}
+}
+
+func TestRunToolDoesNotRetryAfterRequestSent(t *testing.T) {
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	var served, calls int32
+	c := newTestClient(t, statusSequence(&served),
+		config.ServicesConfig{HexStrikeRetries: 3, HexStrikeBreakerFailures: 5, HexStrikeBreakerCooldown: time.Minute})
+	failFirst(c, &calls, 1, readErr)
+
+	if _, err := c.RunTool(context.Background(), "nmap", nil); !errors.Is(err, readErr) {
+		t.Fatalf("err = %v, want the read error", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+
+	// GET 可以安全重送
+	atomic.StoreInt32(&calls, 0)
+	failFirst(c, &calls, 1, readErr)
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("health calls = %d, want 2", n)
+	}
+}
+
+func TestHealthStopsAfterRetries(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, statusSequence(&calls, 503, 503, 503, 503),
+		config.ServicesConfig{HexStrikeRetries: 2, HexStrikeBreakerFailures: 10, HexStrikeBreakerCooldown: time.Minute})
+
+	_, err := c.Health(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want 503 APIError", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("calls = %d, want 3", n)
+	}
+}
+
+func TestRunToolDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"target parameter is required"}`))
+	}, config.ServicesConfig{HexStrikeRetries: 3, HexStrikeBreakerFailures: 1, HexStrikeBreakerCooldown: time.Minute})
+
+	_, err := c.RunTool(context.Background(), "nmap", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "target parameter is required" {
+		t.Fatalf("err = %v, want 400 APIError with JSON message", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+	if c.CircuitOpen() {
+		t.Error("4xx should not count as a breaker failure")
+	}
+}
+
+func TestRunToolDoesNotRetryInvalidJSON(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`<html>not json</html>`))
+	}, config.ServicesConfig{HexStrikeRetries: 3, HexStrikeBreakerFailures: 5, HexStrikeBreakerCooldown: time.Minute})
+
+	_, err := c.RunTool(context.Background(), "nmap", nil)
+	if !errors.Is(err, errInvalidResponse) {
+		t.Fatalf("err = %v, want errInvalidResponse", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
+
+func TestBreakerOpensAtThreshold(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, config.ServicesConfig{HexStrikeRetries: 0, HexStrikeBreakerFailures: 3, HexStrikeBreakerCooldown: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		if c.CircuitOpen() {
+			t.Fatalf("breaker opened after %d failures, want 3", i)
+		}
+		if _, err := c.RunTool(context.Background(), "nmap", nil); err == nil {
+			t.Fatal("RunTool should fail")
+		}
+	}
+	if !c.CircuitOpen() {
+		t.Fatal("breaker should be open after 3 failures")
+	}
+
+	if _, err := c.RunTool(context.Background(), "nmap", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("calls = %d, want 3 (open breaker must not send requests)", n)
+	}
+}
+
+func TestBreakerAllowsSingleHalfOpenProbe(t *testing.T) {
+	var calls int32
+	failing := int32(1)
+	received := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- struct{}{}
+		<-unblock
+		w.Write([]byte(`{"success":true}`))
+	}, config.ServicesConfig{HexStrikeRetries: 0, HexStrikeBreakerFailures: 1, HexStrikeBreakerCooldown: 20 * time.Millisecond})
+
+	if _, err := c.RunTool(context.Background(), "nmap", nil); err == nil {
+		t.Fatal("RunTool should fail")
+	}
+	if _, err := c.RunTool(context.Background(), "nmap", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen during cooldown", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	atomic.StoreInt32(&failing, 0)
+
+	probe := make(chan error, 1)
+	go func() {
+		_, err := c.RunTool(context.Background(), "nmap", nil)
+		probe <- err
+	}()
+	<-received
+
+	// 試探請求進行中，其他請求仍被拒絕
+	if _, err := c.RunTool(context.Background(), "nmap", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen while probing", err)
+	}
+
+	close(unblock)
+	if err := <-probe; err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if c.CircuitOpen() {
+		t.Error("breaker should close after a successful probe")
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+}
+
+func TestCancelledProbeReleasesBreaker(t *testing.T) {
+	var calls int32
+	done := make(chan struct{})
+	defer close(done)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		switch n {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			block(r, done)
+		default:
+			w.Write([]byte(`{"success":true}`))
+		}
+	}, config.ServicesConfig{HexStrikeRetries: 0, HexStrikeBreakerFailures: 1, HexStrikeBreakerCooldown: 10 * time.Millisecond})
+
+	if _, err := c.RunTool(context.Background(), "nmap", nil); err == nil {
+		t.Fatal("RunTool should fail")
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.RunTool(ctx, "nmap", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+
+	// 被取消的試探請求釋放名額，下一個請求可以再次試探
+	if _, err := c.RunTool(context.Background(), "nmap", nil); err != nil {
+		t.Fatalf("RunTool after cancelled probe: %v", err)
+	}
+	if c.CircuitOpen() {
+		t.Error("breaker should close after a successful probe")
+	}
+}
+
+func TestRunToolTimeoutCountsAsFailure(t *testing.T) {
+	var calls int32
+	done := make(chan struct{})
+	defer close(done)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// 模擬卡住的 HexStrike AI：遠超過客戶端逾時才回應
+		select {
+		case <-time.After(5 * time.Second):
+		case <-done:
+		}
+		w.Write([]byte(`{"success":true}`))
+	}, config.ServicesConfig{HexStrikeTimeout: 30 * time.Millisecond, HexStrikeRetries: 3, HexStrikeBreakerFailures: 2, HexStrikeBreakerCooldown: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		_, err := c.RunTool(context.Background(), "nmap", nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("RunTool took %v, want about 30ms", elapsed)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("calls = %d, want 2 (timeouts are not retried)", n)
+	}
+
+	// 連續逾時達門檻後開路，不再送出請求
+	if !c.CircuitOpen() {
+		t.Fatal("breaker should open after repeated timeouts")
+	}
+	if _, err := c.RunTool(context.Background(), "nmap", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+}
+
+func TestCallerCancellationDoesNotCountAsFailure(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		block(r, done)
+	}, config.ServicesConfig{HexStrikeTimeout: time.Minute, HexStrikeBreakerFailures: 1, HexStrikeBreakerCooldown: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.RunTool(ctx, "nmap", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if c.CircuitOpen() {
+		t.Error("the caller's own deadline should not count as a breaker failure")
+	}
+}
+
+func TestRunToolRejectsInvalidToolName(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, statusSequence(&calls), config.ServicesConfig{})
+
+	if _, err := c.RunTool(context.Background(), "../health", nil); err == nil {
+		t.Fatal("RunTool should reject an invalid tool name")
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("calls = %d, want 0", n)
+	}
+}
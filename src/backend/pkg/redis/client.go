@@ -137,6 +137,11 @@ func (c *Client) Info(ctx context.Context) (string, error) {
 	return c.client.Info(ctx).Result()
 }
 
+// PoolStats 取得連線池統計（供 Prometheus 指標使用）
+func (c *Client) PoolStats() *redis.PoolStats {
+	return c.client.PoolStats()
+}
+
 // Stats 取得 Redis 統計資訊
 func (c *Client) Stats() string {
 	stats := c.client.PoolStats()